@@ -27,7 +27,7 @@ func (b *EasyBot) ChooseAction(state engine.GameState, player int) engine.Action
 	switch state.Round.Phase {
 	case engine.PhaseSnos:
 		return discardLowestPoints(state, player, state.Rules.SnosCards)
-	case engine.PhaseBidding:
+	case engine.PhaseBidding, engine.PhaseContra, engine.PhaseRospis:
 		return legal[b.RNG.Intn(len(legal))]
 	case engine.PhasePlayTricks:
 		return legal[b.RNG.Intn(len(legal))]
@@ -65,8 +65,8 @@ func discardLowestPoints(state engine.GameState, player int, count int) engine.A
 	hand := append([]engine.Card(nil), state.Players[player].Hand...)
 	pairSuit := marriagePairs(hand)
 	sort.Slice(hand, func(i, j int) bool {
-		pi := engine.CardPoints(hand[i].Rank)
-		pj := engine.CardPoints(hand[j].Rank)
+		pi := state.Rules.CardValue(hand[i].Rank)
+		pj := state.Rules.CardValue(hand[j].Rank)
 		if pairSuit[hand[i].Suit] && (hand[i].Rank == engine.RankQ || hand[i].Rank == engine.RankK) {
 			pi += 20
 		}
@@ -89,11 +89,11 @@ func bidByHeuristic(state engine.GameState, player int) engine.Action {
 	points := 0
 	suitCounts := map[engine.Suit]int{}
 	for _, c := range hand {
-		points += engine.CardPoints(c.Rank)
+		points += state.Rules.CardValue(c.Rank)
 		suitCounts[c.Suit]++
 	}
 	for suit := range marriagePairs(hand) {
-		points += marriageValue(suit)
+		points += state.Rules.MarriageValue(suit)
 	}
 	bonus := 0
 	for _, c := range suitCounts {
@@ -103,10 +103,7 @@ func bidByHeuristic(state engine.GameState, player int) engine.Action {
 	}
 	estimate := points + bonus
 	maxBid := (estimate / state.Rules.BidStep) * state.Rules.BidStep
-	rulesMax := state.Rules.MaxBid
-	if rulesMax <= 0 {
-		rulesMax = state.Rules.WinScore
-	}
+	rulesMax := engine.MaxBidFor(state, player)
 	if maxBid > rulesMax {
 		maxBid = rulesMax
 	}
@@ -124,7 +121,7 @@ func playHeuristic(state engine.GameState, player int) engine.Action {
 	if len(legal) == 0 {
 		return engine.Action{Type: engine.ActionPass}
 	}
-	if m, ok := bestMarriageAction(legal); ok {
+	if m, ok := bestMarriageAction(state, legal); ok {
 		return m
 	}
 	hand := state.Players[player].Hand
@@ -137,7 +134,7 @@ func playHeuristic(state engine.GameState, player int) engine.Action {
 			if a.Card == nil {
 				continue
 			}
-			score := engine.CardPoints(a.Card.Rank)*10 + engine.RankStrength(a.Card.Rank)
+			score := state.Rules.CardValue(a.Card.Rank)*10 + engine.RankStrength(a.Card.Rank)
 			if score > bestScore {
 				bestScore = score
 				best = a
@@ -171,7 +168,7 @@ func playHeuristic(state engine.GameState, player int) engine.Action {
 		if a.Card == nil {
 			continue
 		}
-		score := engine.CardPoints(a.Card.Rank)*10 + engine.RankStrength(a.Card.Rank)
+		score := state.Rules.CardValue(a.Card.Rank)*10 + engine.RankStrength(a.Card.Rank)
 		if score < lowestScore {
 			lowestScore = score
 			lowest = a
@@ -200,29 +197,17 @@ func marriagePairs(hand []engine.Card) map[engine.Suit]bool {
 	return pairs
 }
 
-func marriageValue(s engine.Suit) int {
-	switch s {
-	case engine.SuitHearts:
-		return 100
-	case engine.SuitDiamonds:
-		return 80
-	case engine.SuitClubs:
-		return 60
-	case engine.SuitSpades:
-		return 40
-	default:
-		return 0
+func bestMarriageAction(state engine.GameState, legal []engine.Action) (engine.Action, bool) {
+	if state.Rules.MarriageOnLeadOnly && len(state.Round.TrickCards) > 0 {
+		return engine.Action{}, false
 	}
-}
-
-func bestMarriageAction(legal []engine.Action) (engine.Action, bool) {
 	best := engine.Action{}
 	bestValue := -1
 	for _, a := range legal {
 		if a.MarriageSuit == nil {
 			continue
 		}
-		val := marriageValue(*a.MarriageSuit)
+		val := state.Rules.MarriageValue(*a.MarriageSuit)
 		if val > bestValue {
 			bestValue = val
 			best = a
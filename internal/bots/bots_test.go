@@ -23,6 +23,24 @@ func TestBotSelfPlayManySeeds(t *testing.T) {
 	}
 }
 
+func TestBotSelfPlayFourPlayers(t *testing.T) {
+	rules := engine.FourPlayerPreset()
+	for seed := int64(1); seed <= 100; seed++ {
+		if err := runBotSelfPlayWithRules(rules, seed, 8, 800); err != nil {
+			t.Fatalf("bot self-play failed: %v", err)
+		}
+	}
+}
+
+func TestBotSelfPlayTwoPlayers(t *testing.T) {
+	rules := engine.TwoPlayerPreset()
+	for seed := int64(1); seed <= 100; seed++ {
+		if err := runBotSelfPlayWithRules(rules, seed, 8, 800); err != nil {
+			t.Fatalf("bot self-play failed: %v", err)
+		}
+	}
+}
+
 func FuzzBotSelfPlay(f *testing.F) {
 	f.Add(int64(1))
 	f.Add(int64(42))
@@ -34,18 +52,110 @@ func FuzzBotSelfPlay(f *testing.F) {
 	})
 }
 
-func runBotSelfPlay(seed int64, rounds int, maxSteps int) error {
+func TestBotSelfPlayKontra(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		if err := runBotSelfPlayWithRules(engine.KontraPreset(), seed, 5, 400); err != nil {
+			t.Fatalf("seed %d failed: %v", seed, err)
+		}
+	}
+}
+
+func TestBotSelfPlayRefusableRospis(t *testing.T) {
+	rules := engine.TisyachaPreset()
+	rules.RospisRefusable = true
+	for seed := int64(1); seed <= 20; seed++ {
+		if err := runBotSelfPlayWithRules(rules, seed, 5, 400); err != nil {
+			t.Fatalf("seed %d failed: %v", seed, err)
+		}
+	}
+}
+
+func TestBotSelfPlayThirtyTwoCards(t *testing.T) {
+	rules := engine.ThirtyTwoCardPreset()
+	for seed := int64(1); seed <= 100; seed++ {
+		if err := runBotSelfPlayWithRules(rules, seed, 8, 800); err != nil {
+			t.Fatalf("bot self-play failed: %v", err)
+		}
+	}
+}
+
+func TestBotSelfPlayBidCap(t *testing.T) {
+	rules := engine.TisyachaPreset()
+	rules.BidCapWithoutMarriage = 120
+	for seed := int64(1); seed <= 100; seed++ {
+		if err := runBotSelfPlayWithRules(rules, seed, 6, 800); err != nil {
+			t.Fatalf("bot self-play failed: %v", err)
+		}
+	}
+}
+
+func TestBidHeuristicRespectsCap(t *testing.T) {
 	rules := engine.TisyachaPreset()
-	state := engine.NewGame(rules, seed)
+	rules.BidMin = 60
+	rules.BidCapWithoutMarriage = 70
+	state, err := engine.NewGame(rules, 1)
+	if err != nil {
+		t.Fatalf("new game: %v", err)
+	}
+	engine.DealRound(&state)
+	player := state.Round.BidTurn
+	state.Players[player].Hand = []engine.Card{
+		{Suit: engine.SuitHearts, Rank: engine.RankA},
+		{Suit: engine.SuitHearts, Rank: engine.Rank10},
+		{Suit: engine.SuitSpades, Rank: engine.RankA},
+		{Suit: engine.SuitSpades, Rank: engine.Rank10},
+		{Suit: engine.SuitClubs, Rank: engine.RankA},
+		{Suit: engine.SuitClubs, Rank: engine.Rank10},
+		{Suit: engine.SuitDiamonds, Rank: engine.RankA},
+	}
+	a := bidByHeuristic(state, player)
+	if a.Type != engine.ActionBid || a.Bid != 70 {
+		t.Fatalf("expected bot to bid the cap of 70, got %+v", a)
+	}
+	if err := engine.ApplyAction(&state, player, a); err != nil {
+		t.Fatalf("bot bid rejected: %v", err)
+	}
+}
 
-	bots := map[int]Bot{
-		0: NewNormal(seed + 10),
-		1: NewEasy(seed + 20),
-		2: NewNormal(seed + 30),
+func TestBestMarriageActionRespectsLeadRule(t *testing.T) {
+	state, err := engine.NewGame(engine.TisyachaPreset(), 1)
+	if err != nil {
+		t.Fatalf("new game: %v", err)
+	}
+	state.Round.TrickCards = []engine.Card{{Suit: engine.SuitClubs, Rank: engine.Rank9}}
+	card := engine.Card{Suit: engine.SuitHearts, Rank: engine.RankK}
+	suit := engine.SuitHearts
+	legal := []engine.Action{{Type: engine.ActionPlayCard, Card: &card, MarriageSuit: &suit}}
+
+	if _, ok := bestMarriageAction(state, legal); ok {
+		t.Fatalf("bot should not declare a marriage while following")
+	}
+	state.Round.TrickCards = nil
+	if _, ok := bestMarriageAction(state, legal); !ok {
+		t.Fatalf("bot should declare a marriage on the lead")
+	}
+}
+
+func runBotSelfPlay(seed int64, rounds int, maxSteps int) error {
+	return runBotSelfPlayWithRules(engine.TisyachaPreset(), seed, rounds, maxSteps)
+}
+
+func runBotSelfPlayWithRules(rules engine.Rules, seed int64, rounds int, maxSteps int) error {
+	state, err := engine.NewGame(rules, seed)
+	if err != nil {
+		return err
+	}
+
+	bots := map[int]Bot{}
+	for p := 0; p < rules.Players; p++ {
+		if p%2 == 0 {
+			bots[p] = NewNormal(seed + int64(p+1)*10)
+		} else {
+			bots[p] = NewEasy(seed + int64(p+1)*10)
+		}
 	}
 
 	for r := 0; r < rounds; r++ {
-		state.Seed = seed + int64(r)
 		engine.DealRound(&state)
 		records := []actionRecord{}
 		for step := 0; step < maxSteps; step++ {
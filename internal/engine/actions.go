@@ -11,6 +11,21 @@ const (
 	ActionSnos
 	ActionPlayCard
 	ActionRospis
+	ActionRaiseContract
+	ActionRequestRedeal
+	ActionContra
+	ActionRecontra
+	ActionRefuseRospis
+)
+
+// RedealReason names the condition that lets a player ask for a redeal.
+type RedealReason int
+
+const (
+	RedealNone RedealReason = iota
+	RedealFourNines
+	RedealWeakHand
+	RedealWeakKitty
 )
 
 type Action struct {
@@ -26,22 +41,34 @@ func LegalActions(g GameState, player int) []Action {
 	// Ordering is deterministic based on rules, bidding increments, and hand order.
 	switch g.Round.Phase {
 	case PhaseBidding:
-		return legalBids(g, player)
+		return append(legalBids(g, player), legalRedeal(g, player)...)
 	case PhaseKittyTake:
 		if player != g.Round.BidWinner {
 			return nil
 		}
-		return []Action{{Type: ActionTakeKitty}}
+		return append([]Action{{Type: ActionTakeKitty}}, legalRedeal(g, player)...)
 	case PhaseSnos:
 		if player != g.Round.BidWinner {
 			return nil
 		}
 		// Too many combinations; client/bot should choose.
 		return []Action{{Type: ActionSnos}}
+	case PhaseRaise:
+		if player != g.Round.BidWinner {
+			return nil
+		}
+		return append([]Action{{Type: ActionPass}}, legalRaises(g, player)...)
+	case PhaseContra:
+		return legalContra(g, player)
+	case PhaseRospis:
+		if player != g.Round.RospisTurn {
+			return nil
+		}
+		return []Action{{Type: ActionPass}, {Type: ActionRefuseRospis}}
 	case PhasePlayTricks:
 		actions := legalPlays(g, player)
-		// bidder may declare rospis before any cards played
-		if player == g.Round.BidWinner && len(g.Round.TrickCards) == 0 && totalTricks(g) == 0 {
+		// bidder may declare rospis before any cards played, unless it was refused
+		if player == g.Round.BidWinner && !g.Round.RospisRefused && len(g.Round.TrickCards) == 0 && totalTricks(g) == 0 {
 			actions = append(actions, Action{Type: ActionRospis})
 		}
 		return actions
@@ -55,11 +82,15 @@ func CurrentPlayer(g GameState) (int, bool) {
 	switch g.Round.Phase {
 	case PhaseBidding:
 		return g.Round.BidTurn, true
-	case PhaseKittyTake, PhaseSnos:
+	case PhaseKittyTake, PhaseRaise, PhaseSnos:
 		if g.Round.BidWinner >= 0 {
 			return g.Round.BidWinner, true
 		}
 		return -1, false
+	case PhaseContra:
+		return g.Round.ContraTurn, true
+	case PhaseRospis:
+		return g.Round.RospisTurn, true
 	case PhasePlayTricks:
 		if len(g.Round.TrickOrder) == 0 {
 			return g.Round.Leader, true
@@ -74,13 +105,22 @@ func CurrentPlayer(g GameState) (int, bool) {
 }
 
 func ApplyAction(g *GameState, player int, a Action) error {
+	if a.Type == ActionRequestRedeal {
+		return applyRequestRedeal(g, player)
+	}
 	switch g.Round.Phase {
 	case PhaseBidding:
 		return applyBid(g, player, a)
 	case PhaseKittyTake:
 		return applyKittyTake(g, player, a)
+	case PhaseRaise:
+		return applyRaiseContract(g, player, a)
 	case PhaseSnos:
 		return applySnos(g, player, a)
+	case PhaseContra:
+		return applyContra(g, player, a)
+	case PhaseRospis:
+		return applyRospisResponse(g, player, a)
 	case PhasePlayTricks:
 		if a.Type == ActionRospis {
 			return applyRospis(g, player, a)
@@ -105,15 +145,12 @@ func applyBid(g *GameState, player int, a Action) error {
 	switch a.Type {
 	case ActionPass:
 		g.Round.Passed[player] = true
+		g.Round.Record.Bids = append(g.Round.Record.Bids, BidRecord{Player: player, Pass: true})
 	case ActionBid:
 		if a.Bid < g.Rules.BidMin {
 			return errors.New("bid below minimum")
 		}
-		maxBid := g.Rules.MaxBid
-		if maxBid <= 0 {
-			maxBid = g.Rules.WinScore
-		}
-		if a.Bid > maxBid {
+		if a.Bid > MaxBidFor(*g, player) {
 			return errors.New("bid above maximum")
 		}
 		if (a.Bid-g.Rules.BidMin)%g.Rules.BidStep != 0 {
@@ -125,25 +162,24 @@ func applyBid(g *GameState, player int, a Action) error {
 		g.Round.BidValue = a.Bid
 		g.Round.BidWinner = player
 		g.Round.Bids[player] = a.Bid
+		g.Round.Record.Bids = append(g.Round.Record.Bids, BidRecord{Player: player, Bid: a.Bid})
 	default:
 		return errors.New("invalid action for bidding")
 	}
 
 	// Advance bidding
 	active := 0
-	for p := 0; p < g.Rules.Players; p++ {
+	for _, p := range activeSeats(*g) {
 		if !g.Round.Passed[p] {
 			active++
 		}
 	}
 	if active == 1 && g.Round.BidWinner >= 0 {
-		g.Round.Phase = PhaseKittyTake
+		startKittyTake(g)
 		return nil
 	}
 	if active == 0 {
-		// All passed, redeal next round
-		g.Round.Dealer = (g.Round.Dealer + 1) % g.Rules.Players
-		g.ResetRound()
+		applyAllPass(g)
 		return nil
 	}
 
@@ -151,6 +187,60 @@ func applyBid(g *GameState, player int, a Action) error {
 	return nil
 }
 
+func applyRequestRedeal(g *GameState, player int) error {
+	if len(legalRedeal(*g, player)) == 0 {
+		return errors.New("redeal not allowed")
+	}
+	recordRound(g, OutcomeRedealRequested)
+	g.ResetRound()
+	return nil
+}
+
+func applyAllPass(g *GameState) {
+	switch g.Rules.AllPassPolicy {
+	case AllPassForcedBid:
+		steps := g.Rules.ForcedBidSeat
+		if steps <= 0 {
+			steps = 1
+		}
+		seat := g.Round.Dealer
+		for i := 0; i < steps; i++ {
+			seat = nextSeat(*g, seat)
+		}
+		bid := g.Rules.ForcedBidValue
+		if bid <= 0 {
+			bid = g.Rules.BidMin
+		}
+		g.Round.BidWinner = seat
+		g.Round.BidValue = bid
+		g.Round.Bids[seat] = bid
+		g.Round.ForcedBid = true
+		startKittyTake(g)
+	case AllPassRaspasy:
+		g.Round.Raspasy = true
+		g.Round.BidWinner = -1
+		g.Round.BidValue = 0
+		g.Round.Phase = PhasePlayTricks
+		g.Round.Leader = nextSeat(*g, g.Round.Dealer)
+		g.Round.TrickCards = nil
+		g.Round.TrickOrder = nil
+	default:
+		// Redeal next round
+		recordRound(g, OutcomeAllPassed)
+		g.Round.Dealer = (g.Round.Dealer + 1) % g.Rules.Players
+		g.ResetRound()
+	}
+}
+
+// startKittyTake closes the bidding and, under face-up rules, shows the
+// kitty to every player before the bidder takes it.
+func startKittyTake(g *GameState) {
+	g.Round.Phase = PhaseKittyTake
+	if g.Rules.KittyFaceUp {
+		g.Round.RevealedKitty = append([]Card(nil), g.Round.Kitty...)
+	}
+}
+
 func applyKittyTake(g *GameState, player int, a Action) error {
 	if player != g.Round.BidWinner {
 		return errors.New("only bidder takes kitty")
@@ -161,6 +251,37 @@ func applyKittyTake(g *GameState, player int, a Action) error {
 	g.Players[player].Hand = append(g.Players[player].Hand, g.Round.Kitty...)
 	g.Round.Kitty = nil
 	g.Round.Phase = PhaseSnos
+	if g.Rules.AllowContractRaise {
+		g.Round.Phase = PhaseRaise
+	}
+	return nil
+}
+
+// applyRaiseContract lets the bidder raise the contract once or keep it
+// with a pass; either way the round moves on to the snos.
+func applyRaiseContract(g *GameState, player int, a Action) error {
+	if player != g.Round.BidWinner {
+		return errors.New("only bidder can raise the contract")
+	}
+	if a.Type == ActionPass {
+		g.Round.Phase = PhaseSnos
+		return nil
+	}
+	if a.Type != ActionRaiseContract {
+		return errors.New("invalid action for raise")
+	}
+	if a.Bid <= g.Round.BidValue {
+		return errors.New("raise must exceed current contract")
+	}
+	if a.Bid > MaxBidFor(*g, player) {
+		return errors.New("bid above maximum")
+	}
+	if (a.Bid-g.Round.BidValue)%g.Rules.BidStep != 0 {
+		return errors.New("invalid bid step")
+	}
+	g.Round.BidValue = a.Bid
+	g.Round.ContractRaised = true
+	g.Round.Phase = PhaseSnos
 	return nil
 }
 
@@ -172,29 +293,94 @@ func applySnos(g *GameState, player int, a Action) error {
 		return errors.New("snos requires exact number of cards")
 	}
 
+	// Check every resulting hand before touching any, so a rejected snos
+	// leaves the round as it was.
+	hand := append([]Card(nil), g.Players[player].Hand...)
 	for _, c := range a.Cards {
-		if !removeCard(&g.Players[player].Hand, c) {
+		if !removeCard(&hand, c) {
 			return errors.New("snos card not in hand")
 		}
 	}
-	opponents := orderedOpponents(player, g.Rules.Players)
-	for i, c := range a.Cards {
-		if i < len(opponents) {
-			g.Players[opponents[i]].Hand = append(g.Players[opponents[i]].Hand, c)
-		}
-	}
-	if len(g.Players[player].Hand) != g.Rules.PlayHandSize {
+	if len(hand) != g.Rules.PlayHandSize {
 		return errors.New("invalid hand size after snos")
 	}
-	for _, opp := range opponents {
-		if len(g.Players[opp].Hand) != g.Rules.PlayHandSize {
+	opponents := orderedOpponents(*g, player)
+	for i, opp := range opponents {
+		size := len(g.Players[opp].Hand)
+		if !g.Rules.SnosToDeadHand && i < len(a.Cards) {
+			size++
+		}
+		if size != g.Rules.PlayHandSize {
 			return errors.New("invalid opponent hand size after snos")
 		}
 	}
+
+	g.Players[player].Hand = hand
+	g.Round.Record.Snos = append([]Card(nil), a.Cards...)
+	if g.Rules.SnosToDeadHand {
+		g.Round.DeadHand = append(g.Round.DeadHand, a.Cards...)
+	} else {
+		for i, c := range a.Cards {
+			if i < len(opponents) {
+				g.Players[opponents[i]].Hand = append(g.Players[opponents[i]].Hand, c)
+			}
+		}
+	}
+	if g.Rules.AllowContra {
+		g.Round.Phase = PhaseContra
+		g.Round.ContraTurn = opponents[0]
+		return nil
+	}
+	startTricks(g)
+	return nil
+}
+
+// startTricks hands the lead to the bidder once the contract is settled.
+func startTricks(g *GameState) {
 	g.Round.Phase = PhasePlayTricks
 	g.Round.Leader = g.Round.BidWinner
 	g.Round.TrickCards = nil
 	g.Round.TrickOrder = nil
+	g.Round.ContraTurn = -1
+}
+
+// applyContra walks the defenders in seat order; the first to double hands
+// the turn back to the bidder, who may redouble or play on.
+func applyContra(g *GameState, player int, a Action) error {
+	if player != g.Round.ContraTurn {
+		return errors.New("not your turn")
+	}
+	contract := g.Round.BidWinner
+	switch a.Type {
+	case ActionContra:
+		if player == contract || g.Round.ContraBy >= 0 {
+			return errors.New("contra not allowed")
+		}
+		g.Round.Multiplier = 2
+		g.Round.ContraBy = player
+		g.Round.ContraTurn = contract
+	case ActionRecontra:
+		if player != contract || g.Round.ContraBy < 0 {
+			return errors.New("recontra not allowed")
+		}
+		g.Round.Multiplier = 4
+		startTricks(g)
+	case ActionPass:
+		if player == contract {
+			startTricks(g)
+			return nil
+		}
+		opponents := orderedOpponents(*g, contract)
+		for i, p := range opponents {
+			if p == player && i+1 < len(opponents) {
+				g.Round.ContraTurn = opponents[i+1]
+				return nil
+			}
+		}
+		startTricks(g)
+	default:
+		return errors.New("invalid action for contra")
+	}
 	return nil
 }
 
@@ -203,7 +389,7 @@ func applyPlay(g *GameState, player int, a Action) error {
 		return errors.New("invalid play action")
 	}
 	if len(g.Round.TrickOrder) == 0 {
-		g.Round.TrickOrder = buildTrickOrder(g.Round.Leader, g.Rules.Players)
+		g.Round.TrickOrder = buildTrickOrder(*g, g.Round.Leader)
 	}
 	expected := g.Round.TrickOrder[len(g.Round.TrickCards)]
 	if player != expected {
@@ -229,9 +415,17 @@ func applyPlay(g *GameState, player int, a Action) error {
 	}
 
 	g.Round.TrickCards = append(g.Round.TrickCards, *a.Card)
-	if len(g.Round.TrickCards) == g.Rules.Players {
+	if len(g.Round.TrickCards) == len(g.Round.TrickOrder) {
 		winner := trickWinner(g.Round.TrickOrder, g.Round.TrickCards, g.Round.Trump)
 		g.Players[winner].Tricks = append(g.Players[winner].Tricks, append([]Card(nil), g.Round.TrickCards...))
+		plays := make([]Play, len(g.Round.TrickCards))
+		for i, c := range g.Round.TrickCards {
+			plays[i] = Play{Player: g.Round.TrickOrder[i], Card: c}
+		}
+		g.Round.Record.Tricks = append(g.Round.Record.Tricks, TrickRecord{Plays: plays, Winner: winner})
+		if g.Round.Raspasy && g.Rules.RaspasyKitty == RaspasyKittyFirstTrick && g.Round.KittyOwner < 0 {
+			g.Round.KittyOwner = winner
+		}
 		g.Round.Leader = winner
 		g.Round.TrickCards = nil
 		g.Round.TrickOrder = nil
@@ -251,26 +445,77 @@ func applyRospis(g *GameState, player int, a Action) error {
 	if len(g.Round.TrickCards) != 0 || totalTricks(*g) != 0 {
 		return errors.New("rospis only before any tricks played")
 	}
+	if g.Round.RospisRefused {
+		return errors.New("rospis already refused")
+	}
 	if a.Type != ActionRospis {
 		return errors.New("invalid rospis action")
 	}
+	if g.Rules.RospisRefusable {
+		g.Round.Phase = PhaseRospis
+		g.Round.RospisTurn = orderedOpponents(*g, player)[0]
+		return nil
+	}
+	settleRospis(g)
+	return nil
+}
+
+// applyRospisResponse lets each defender in seat order accept the rospis
+// with a pass; a single refusal sends the bidder back to play the hand.
+func applyRospisResponse(g *GameState, player int, a Action) error {
+	if player != g.Round.RospisTurn {
+		return errors.New("not your turn")
+	}
+	switch a.Type {
+	case ActionRefuseRospis:
+		g.Round.RospisRefused = true
+		g.Round.RospisTurn = -1
+		g.Round.Phase = PhasePlayTricks
+	case ActionPass:
+		opponents := orderedOpponents(*g, g.Round.BidWinner)
+		for i, p := range opponents {
+			if p == player && i+1 < len(opponents) {
+				g.Round.RospisTurn = opponents[i+1]
+				return nil
+			}
+		}
+		settleRospis(g)
+	default:
+		return errors.New("invalid action for rospis")
+	}
+	return nil
+}
+
+// settleRospis charges the bidder the contract, pays each defender per the
+// rospis policy and finishes the round like a played one.
+func settleRospis(g *GameState) {
+	bidder := g.Round.BidWinner
 	bid := g.Round.BidValue
 	if bid == 0 && g.Round.Bids != nil {
-		if v, ok := g.Round.Bids[player]; ok {
+		if v, ok := g.Round.Bids[bidder]; ok {
 			bid = v
 		}
 	}
-	g.Players[player].GameScore -= bid
-	half := bid / 2
-	for i := range g.Players {
-		if i == player {
-			continue
-		}
-		g.Players[i].GameScore += half
+	bid *= g.Round.StakeMultiplier()
+
+	pay := bid / 2
+	switch g.Rules.RospisPolicy {
+	case RospisHalfRounded:
+		pay = roundPoints(RoundingNearest5, pay)
+	case RospisFixed:
+		pay = g.Rules.RospisFixedPoints
 	}
-	g.Round.Dealer = (g.Round.Dealer + 1) % g.Rules.Players
-	g.ResetRound()
-	return nil
+
+	g.LastRoundEffects = RoundEffects{Winner: -1}
+	gains := make([]int, len(g.Players))
+	g.Players[bidder].GameScore -= bid
+	gains[bidder] = -bid
+	g.LastRoundEffects.Rospis = append(g.LastRoundEffects.Rospis, ScoreChange{Player: bidder, Points: -bid})
+	for _, i := range orderedOpponents(*g, bidder) {
+		gains[i] = creditDefender(g, i, pay)
+		g.LastRoundEffects.Rospis = append(g.LastRoundEffects.Rospis, ScoreChange{Player: i, Points: gains[i]})
+	}
+	finishRound(g, bidder, false, gains, OutcomeRospis)
 }
 
 func legalBids(g GameState, player int) []Action {
@@ -278,11 +523,7 @@ func legalBids(g GameState, player int) []Action {
 		return nil
 	}
 	out := []Action{{Type: ActionPass}}
-	maxBid := g.Rules.MaxBid
-	if maxBid <= 0 {
-		maxBid = g.Rules.WinScore
-	}
-	for bid := g.Rules.BidMin; bid <= maxBid; bid += g.Rules.BidStep {
+	for bid := g.Rules.BidMin; bid <= MaxBidFor(g, player); bid += g.Rules.BidStep {
 		if bid > g.Round.BidValue {
 			out = append(out, Action{Type: ActionBid, Bid: bid})
 		}
@@ -290,12 +531,131 @@ func legalBids(g GameState, player int) []Action {
 	return out
 }
 
+func legalContra(g GameState, player int) []Action {
+	if player != g.Round.ContraTurn {
+		return nil
+	}
+	if player != g.Round.BidWinner {
+		return []Action{{Type: ActionPass}, {Type: ActionContra}}
+	}
+	if g.Round.ContraBy >= 0 {
+		return []Action{{Type: ActionPass}, {Type: ActionRecontra}}
+	}
+	return nil
+}
+
+func legalRedeal(g GameState, player int) []Action {
+	switch g.Round.Phase {
+	case PhaseBidding:
+		if player != g.Round.BidTurn || g.Round.Passed[player] {
+			return nil
+		}
+		if _, bid := g.Round.Bids[player]; bid {
+			return nil
+		}
+	case PhaseKittyTake:
+		if player != g.Round.BidWinner {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if reason, _ := RedealTrigger(g, player); reason == RedealNone {
+		return nil
+	}
+	return []Action{{Type: ActionRequestRedeal}}
+}
+
+// RedealTrigger reports which redeal condition the player meets, together
+// with the cards that meet it. Hand conditions apply while bidding; the
+// kitty condition applies to the bidder before the kitty is taken.
+func RedealTrigger(g GameState, player int) (RedealReason, []Card) {
+	switch g.Round.Phase {
+	case PhaseBidding:
+		hand := g.Players[player].Hand
+		if g.Rules.RedealFourNines {
+			nines := []Card{}
+			for _, c := range hand {
+				if c.Rank == Rank9 {
+					nines = append(nines, c)
+				}
+			}
+			if len(nines) == 4 {
+				return RedealFourNines, nines
+			}
+		}
+		if g.Rules.RedealHandBelow > 0 && handPoints(g.Rules, hand) < g.Rules.RedealHandBelow {
+			return RedealWeakHand, append([]Card(nil), hand...)
+		}
+	case PhaseKittyTake:
+		if g.Rules.RedealKittyBelow > 0 && player == g.Round.BidWinner && handPoints(g.Rules, g.Round.Kitty) < g.Rules.RedealKittyBelow {
+			return RedealWeakKitty, append([]Card(nil), g.Round.Kitty...)
+		}
+	}
+	return RedealNone, nil
+}
+
+func handPoints(r Rules, cards []Card) int {
+	total := 0
+	for _, c := range cards {
+		total += r.CardValue(c.Rank)
+	}
+	return total
+}
+
+func legalRaises(g GameState, player int) []Action {
+	if !g.Rules.AllowContractRaise || g.Round.ContractRaised || player != g.Round.BidWinner {
+		return nil
+	}
+	out := []Action{}
+	for bid := g.Round.BidValue + g.Rules.BidStep; bid <= MaxBidFor(g, player); bid += g.Rules.BidStep {
+		out = append(out, Action{Type: ActionRaiseContract, Bid: bid})
+	}
+	return out
+}
+
+func maxBid(r Rules) int {
+	if r.MaxBid <= 0 {
+		return r.WinScore
+	}
+	return r.MaxBid
+}
+
+// MaxBidFor returns the highest bid the player may make with their current
+// hand, applying BidCapWithoutMarriage when they hold no Q+K pair.
+func MaxBidFor(g GameState, player int) int {
+	limit := maxBid(g.Rules)
+	if g.Rules.BidCapWithoutMarriage > 0 && g.Rules.BidCapWithoutMarriage < limit && !holdsMarriage(g.Players[player].Hand) {
+		limit = g.Rules.BidCapWithoutMarriage
+	}
+	return limit
+}
+
+func holdsMarriage(hand []Card) bool {
+	hasQ := map[Suit]bool{}
+	hasK := map[Suit]bool{}
+	for _, c := range hand {
+		if c.Rank == RankQ {
+			hasQ[c.Suit] = true
+		}
+		if c.Rank == RankK {
+			hasK[c.Suit] = true
+		}
+	}
+	for s := range hasQ {
+		if hasK[s] {
+			return true
+		}
+	}
+	return false
+}
+
 func legalPlays(g GameState, player int) []Action {
 	if g.Round.Phase != PhasePlayTricks {
 		return nil
 	}
 	if len(g.Round.TrickOrder) == 0 {
-		g.Round.TrickOrder = buildTrickOrder(g.Round.Leader, g.Rules.Players)
+		g.Round.TrickOrder = buildTrickOrder(g, g.Round.Leader)
 	}
 	expected := g.Round.TrickOrder[len(g.Round.TrickCards)]
 	if player != expected {
@@ -305,14 +665,38 @@ func legalPlays(g GameState, player int) []Action {
 	if len(hand) == 0 {
 		return nil
 	}
-	// If must follow suit and trick has led suit, restrict.
-	if g.Rules.MustFollowSuit && len(g.Round.TrickCards) > 0 {
-		led := g.Round.TrickCards[0].Suit
-		if hasSuit(hand, led) {
-			return cardsToActions(filterBySuit(hand, led), g, player)
-		}
+	if len(g.Round.TrickCards) == 0 {
+		return cardsToActions(hand, g, player)
 	}
-	return cardsToActions(hand, g, player)
+	led := g.Round.TrickCards[0].Suit
+	trump := g.Round.Trump
+	candidates := hand
+	// If must follow suit and trick has led suit, restrict.
+	if g.Rules.MustFollowSuit && hasSuit(hand, led) {
+		candidates = filterBySuit(hand, led)
+	} else if g.Rules.MustTrumpIfVoid && trump != nil && !hasSuit(hand, led) && hasSuit(hand, *trump) {
+		candidates = filterBySuit(hand, *trump)
+	}
+	// A trump already on the trick must be beaten by a higher trump if possible.
+	if g.Rules.MustOverTrump && trump != nil {
+		if top, ok := highestOfSuit(g.Round.TrickCards, *trump); ok {
+			out := []Card{}
+			canOverTrump := false
+			for _, c := range candidates {
+				beats := c.Suit == *trump && rankStrength(c.Rank) > rankStrength(top.Rank)
+				if beats {
+					canOverTrump = true
+				}
+				if c.Suit != *trump || beats {
+					out = append(out, c)
+				}
+			}
+			if canOverTrump {
+				candidates = out
+			}
+		}
+	}
+	return cardsToActions(candidates, g, player)
 }
 
 func cardsToActions(cards []Card, g GameState, player int) []Action {
@@ -330,10 +714,11 @@ func cardsToActions(cards []Card, g GameState, player int) []Action {
 	return out
 }
 
-func buildTrickOrder(leader, players int) []int {
-	order := make([]int, 0, players)
-	for i := 0; i < players; i++ {
-		order = append(order, (leader+i)%players)
+func buildTrickOrder(g GameState, leader int) []int {
+	n := g.Rules.ActivePlayers()
+	order := make([]int, 0, n)
+	for p := leader; len(order) < n; p = nextSeat(g, p) {
+		order = append(order, p)
 	}
 	return order
 }
@@ -341,13 +726,39 @@ func buildTrickOrder(leader, players int) []int {
 func nextBidTurn(g *GameState) int {
 	for i := 1; i <= g.Rules.Players; i++ {
 		n := (g.Round.BidTurn + i) % g.Rules.Players
-		if !g.Round.Passed[n] {
+		if InRound(*g, n) && !g.Round.Passed[n] {
 			return n
 		}
 	}
 	return g.Round.BidTurn
 }
 
+// InRound reports whether a seat takes part in the current round.
+func InRound(g GameState, player int) bool {
+	return !(g.Rules.DealerSitsOut && player == g.Round.Dealer)
+}
+
+// nextSeat returns the first seat after from that takes part in the round.
+func nextSeat(g GameState, from int) int {
+	for i := 1; i <= g.Rules.Players; i++ {
+		n := (from + i) % g.Rules.Players
+		if InRound(g, n) {
+			return n
+		}
+	}
+	return from
+}
+
+func activeSeats(g GameState) []int {
+	out := make([]int, 0, g.Rules.ActivePlayers())
+	for p := 0; p < g.Rules.Players; p++ {
+		if InRound(g, p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
 func hasSuit(cards []Card, suit Suit) bool {
 	for _, c := range cards {
 		if c.Suit == suit {
@@ -357,6 +768,21 @@ func hasSuit(cards []Card, suit Suit) bool {
 	return false
 }
 
+func highestOfSuit(cards []Card, suit Suit) (Card, bool) {
+	best := Card{}
+	found := false
+	for _, c := range cards {
+		if c.Suit != suit {
+			continue
+		}
+		if !found || rankStrength(c.Rank) > rankStrength(best.Rank) {
+			best = c
+			found = true
+		}
+	}
+	return best, found
+}
+
 func filterBySuit(cards []Card, suit Suit) []Card {
 	out := []Card{}
 	for _, c := range cards {
@@ -393,14 +819,19 @@ func suitPtr(s Suit) *Suit {
 	return &s
 }
 
-func orderedOpponents(player int, players int) []int {
+func orderedOpponents(g GameState, player int) []int {
 	out := []int{}
-	for i := 1; i < players; i++ {
-		out = append(out, (player+i)%players)
+	for p := nextSeat(g, player); len(out) < g.Rules.ActivePlayers()-1; p = nextSeat(g, p) {
+		out = append(out, p)
 	}
 	return out
 }
 
+// Opponents returns the players who receive the bidder's snos, in order.
+func Opponents(g GameState, player int) []int {
+	return orderedOpponents(g, player)
+}
+
 func totalTricks(g GameState) int {
 	total := 0
 	for _, p := range g.Players {
@@ -416,6 +847,9 @@ func canDeclareMarriage(g GameState, player int, card Card) bool {
 	if g.Rules.MarriageRequiresTrick && len(g.Players[player].Tricks) == 0 {
 		return false
 	}
+	if g.Rules.MarriageOnLeadOnly && len(g.Round.TrickCards) > 0 {
+		return false
+	}
 	if card.Rank != RankQ && card.Rank != RankK {
 		return false
 	}
@@ -449,6 +883,9 @@ func applyMarriage(g *GameState, player int, played Card, suit Suit) error {
 	if g.Rules.MarriageRequiresTrick && len(g.Players[player].Tricks) == 0 {
 		return errors.New("marriage requires at least one trick")
 	}
+	if g.Rules.MarriageOnLeadOnly && len(g.Round.TrickCards) > 0 {
+		return errors.New("marriage only on lead")
+	}
 	if g.Round.DeclaredMarriages[player] == nil {
 		g.Round.DeclaredMarriages[player] = make(map[Suit]bool)
 	}
@@ -473,7 +910,8 @@ func applyMarriage(g *GameState, player int, played Card, suit Suit) error {
 		return errors.New("marriage requires Q and K in hand")
 	}
 	g.Round.DeclaredMarriages[player][suit] = true
-	g.Players[player].MarriagePts += marriageValue(suit)
+	g.Players[player].MarriagePts += g.Rules.MarriageValue(suit)
+	g.Round.Record.Marriages = append(g.Round.Record.Marriages, MarriageRecord{Player: player, Suit: suit, Points: g.Rules.MarriageValue(suit)})
 	g.Round.Trump = &suit
 	return nil
 }
@@ -501,6 +939,7 @@ func applyAceMarriage(g *GameState, player int, played Card) error {
 		return nil
 	}
 	g.Round.DeclaredAceMarriage[player] = true
-	g.Players[player].MarriagePts += 200
+	g.Players[player].MarriagePts += g.Rules.AceMarriagePoints
+	g.Round.Record.Marriages = append(g.Round.Record.Marriages, MarriageRecord{Player: player, Ace: true, Points: g.Rules.AceMarriagePoints})
 	return nil
 }
@@ -4,7 +4,7 @@ import "testing"
 
 func TestBidValidation(t *testing.T) {
 	r := ClassicPreset()
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	DealRound(&g)
 
 	player := g.Round.BidTurn
@@ -18,7 +18,7 @@ func TestBidValidation(t *testing.T) {
 
 func TestLegalPlaysFollowSuit(t *testing.T) {
 	r := ClassicPreset()
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	g.Round.Phase = PhasePlayTricks
 	g.Round.Leader = 0
 	g.Round.TrickOrder = []int{0, 1, 2}
@@ -38,10 +38,75 @@ func TestLegalPlaysFollowSuit(t *testing.T) {
 	}
 }
 
+func TestLegalPlaysMustTrumpIfVoid(t *testing.T) {
+	r := ClassicPreset()
+	r.MustTrumpIfVoid = true
+	g := newTestGame(t, r, 1)
+	g.Round.Phase = PhasePlayTricks
+	g.Round.Leader = 0
+	g.Round.Trump = suitPtr(SuitSpades)
+	g.Round.TrickOrder = []int{0, 1, 2}
+	g.Round.TrickCards = []Card{{Suit: SuitHearts, Rank: RankA}}
+
+	g.Players[1].Hand = []Card{
+		{Suit: SuitClubs, Rank: RankA},
+		{Suit: SuitSpades, Rank: Rank9},
+	}
+
+	actions := LegalActions(g, 1)
+	if len(actions) != 1 || actions[0].Card.Suit != SuitSpades {
+		t.Fatalf("expected only the trump to be legal, got %v", actions)
+	}
+
+	r.MustTrumpIfVoid = false
+	g.Rules = r
+	if actions := LegalActions(g, 1); len(actions) != 2 {
+		t.Fatalf("expected any card to be legal without the rule, got %d", len(actions))
+	}
+}
+
+func TestLegalPlaysMustOverTrump(t *testing.T) {
+	r := ClassicPreset()
+	r.MustTrumpIfVoid = true
+	r.MustOverTrump = true
+	g := newTestGame(t, r, 1)
+	g.Round.Phase = PhasePlayTricks
+	g.Round.Leader = 0
+	g.Round.Trump = suitPtr(SuitSpades)
+	g.Round.TrickOrder = []int{0, 1, 2}
+	g.Round.TrickCards = []Card{
+		{Suit: SuitHearts, Rank: RankA},
+		{Suit: SuitSpades, Rank: RankQ},
+	}
+
+	g.Players[2].Hand = []Card{
+		{Suit: SuitSpades, Rank: RankJ},
+		{Suit: SuitSpades, Rank: RankK},
+		{Suit: SuitClubs, Rank: Rank9},
+	}
+
+	actions := LegalActions(g, 2)
+	if len(actions) != 1 || *actions[0].Card != (Card{Suit: SuitSpades, Rank: RankK}) {
+		t.Fatalf("expected only the higher trump to be legal, got %v", actions)
+	}
+	low := Card{Suit: SuitSpades, Rank: RankJ}
+	if err := ApplyAction(&g, 2, Action{Type: ActionPlayCard, Card: &low}); err == nil {
+		t.Fatalf("expected under-trump to be rejected")
+	}
+
+	g.Players[2].Hand = []Card{
+		{Suit: SuitSpades, Rank: RankJ},
+		{Suit: SuitSpades, Rank: Rank9},
+	}
+	if actions := LegalActions(g, 2); len(actions) != 2 {
+		t.Fatalf("expected any trump when unable to overtrump, got %d", len(actions))
+	}
+}
+
 func TestLegalBidsRespectsMaxBid(t *testing.T) {
 	r := ClassicPreset()
 	r.MaxBid = 200
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	DealRound(&g)
 
 	acts := LegalActions(g, g.Round.BidTurn)
@@ -52,9 +117,101 @@ func TestLegalBidsRespectsMaxBid(t *testing.T) {
 	}
 }
 
+func TestBidCapWithoutMarriage(t *testing.T) {
+	r := ClassicPreset()
+	r.BidCapWithoutMarriage = 120
+	g := newTestGame(t, r, 1)
+	DealRound(&g)
+	player := g.Round.BidTurn
+	g.Players[player].Hand = []Card{
+		{Suit: SuitHearts, Rank: RankQ},
+		{Suit: SuitSpades, Rank: RankK},
+		{Suit: SuitClubs, Rank: RankA},
+	}
+
+	for _, a := range LegalActions(g, player) {
+		if a.Type == ActionBid && a.Bid > 120 {
+			t.Fatalf("bid %d offered without a marriage", a.Bid)
+		}
+	}
+	if err := ApplyAction(&g, player, Action{Type: ActionBid, Bid: 130}); err == nil {
+		t.Fatalf("expected bid over cap to be rejected")
+	}
+
+	g.Players[player].Hand = append(g.Players[player].Hand, Card{Suit: SuitHearts, Rank: RankK})
+	if MaxBidFor(g, player) != r.MaxBid {
+		t.Fatalf("expected full bid range with a marriage, got %d", MaxBidFor(g, player))
+	}
+	if err := ApplyAction(&g, player, Action{Type: ActionBid, Bid: 130}); err != nil {
+		t.Fatalf("bid with marriage rejected: %v", err)
+	}
+}
+
+func TestRequestRedealFourNines(t *testing.T) {
+	r := ClassicPreset()
+	r.RedealFourNines = true
+	g := newTestGame(t, r, 1)
+	DealRound(&g)
+	player := g.Round.BidTurn
+	g.Players[player].GameScore = 50
+
+	hasRedeal := func() bool {
+		for _, a := range LegalActions(g, player) {
+			if a.Type == ActionRequestRedeal {
+				return true
+			}
+		}
+		return false
+	}
+	g.Players[player].Hand = []Card{
+		{Suit: SuitHearts, Rank: Rank9},
+		{Suit: SuitSpades, Rank: Rank9},
+		{Suit: SuitClubs, Rank: Rank9},
+		{Suit: SuitDiamonds, Rank: RankA},
+	}
+	if hasRedeal() {
+		t.Fatalf("redeal offered with three nines")
+	}
+	if err := ApplyAction(&g, player, Action{Type: ActionRequestRedeal}); err == nil {
+		t.Fatalf("expected redeal to be refused")
+	}
+
+	g.Players[player].Hand[3] = Card{Suit: SuitDiamonds, Rank: Rank9}
+	if !hasRedeal() {
+		t.Fatalf("expected redeal to be offered with four nines")
+	}
+	reason, cards := RedealTrigger(g, player)
+	if reason != RedealFourNines || len(cards) != 4 {
+		t.Fatalf("unexpected trigger %v %v", reason, cards)
+	}
+	if err := ApplyAction(&g, player, Action{Type: ActionRequestRedeal}); err != nil {
+		t.Fatalf("redeal failed: %v", err)
+	}
+	if g.Round.Phase != PhaseDeal || g.Round.Dealer != 0 || g.Players[player].GameScore != 50 {
+		t.Fatalf("expected reset round with same dealer and score, got phase %v dealer %d score %d", g.Round.Phase, g.Round.Dealer, g.Players[player].GameScore)
+	}
+}
+
+func TestRequestRedealWeakKitty(t *testing.T) {
+	r := ClassicPreset()
+	r.RedealKittyBelow = 5
+	g := newTestGame(t, r, 1)
+	g.Round.Phase = PhaseKittyTake
+	g.Round.BidWinner = 1
+	g.Round.Kitty = []Card{{Suit: SuitHearts, Rank: Rank9}, {Suit: SuitClubs, Rank: RankJ}, {Suit: SuitSpades, Rank: Rank9}}
+
+	if acts := LegalActions(g, 1); len(acts) != 2 || acts[1].Type != ActionRequestRedeal {
+		t.Fatalf("expected take and redeal, got %v", acts)
+	}
+	g.Round.Kitty[0] = Card{Suit: SuitHearts, Rank: RankA}
+	if acts := LegalActions(g, 1); len(acts) != 1 {
+		t.Fatalf("expected only take with a rich kitty, got %v", acts)
+	}
+}
+
 func TestApplyActionRejectsIllegal(t *testing.T) {
 	r := ClassicPreset()
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	DealRound(&g)
 	// Not the bid turn should fail
 	illegalPlayer := (g.Round.BidTurn + 1) % r.Players
@@ -70,7 +227,7 @@ func TestSnosDistributesToOpponents(t *testing.T) {
 	r.PlayHandSize = 8
 	r.KittySize = 3
 	r.SnosCards = 2
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	g.Round.Phase = PhaseSnos
 	g.Round.BidWinner = 0
 	g.Players[0].Hand = []Card{
@@ -107,6 +264,233 @@ func TestSnosDistributesToOpponents(t *testing.T) {
 	}
 }
 
+func TestSnosToDeadHand(t *testing.T) {
+	r := TwoPlayerPreset()
+	g := newTestGame(t, r, 1)
+	g.Round.Phase = PhaseSnos
+	g.Round.BidWinner = 1
+	g.Players[1].Hand = []Card{
+		{Suit: SuitHearts, Rank: RankA},
+		{Suit: SuitSpades, Rank: Rank10},
+		{Suit: SuitClubs, Rank: RankK},
+		{Suit: SuitDiamonds, Rank: RankQ},
+		{Suit: SuitHearts, Rank: RankJ},
+		{Suit: SuitSpades, Rank: Rank9},
+		{Suit: SuitClubs, Rank: Rank9},
+		{Suit: SuitDiamonds, Rank: Rank9},
+		{Suit: SuitHearts, Rank: Rank10},
+		{Suit: SuitSpades, Rank: RankA},
+	}
+	g.Players[0].Hand = make([]Card, 7)
+	snos := []Card{g.Players[1].Hand[5], g.Players[1].Hand[6], g.Players[1].Hand[7]}
+
+	if err := ApplyAction(&g, 1, Action{Type: ActionSnos, Cards: snos}); err != nil {
+		t.Fatalf("snos failed: %v", err)
+	}
+	if len(g.Players[0].Hand) != r.PlayHandSize || len(g.Players[1].Hand) != r.PlayHandSize {
+		t.Fatalf("expected both hands to hold %d cards, got %d/%d", r.PlayHandSize, len(g.Players[0].Hand), len(g.Players[1].Hand))
+	}
+	for _, c := range snos {
+		if !containsCard(g.Round.DeadHand, c) {
+			t.Fatalf("snos card %v not in dead hand", c)
+		}
+	}
+}
+
+func TestRaiseContractAfterKitty(t *testing.T) {
+	r := RaisePreset()
+	g := newTestGame(t, r, 1)
+	g.Round.Phase = PhaseKittyTake
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 100
+
+	if err := ApplyAction(&g, 0, Action{Type: ActionTakeKitty}); err != nil {
+		t.Fatalf("take kitty failed: %v", err)
+	}
+	if g.Round.Phase != PhaseRaise {
+		t.Fatalf("expected raise step after the kitty, got %v", g.Round.Phase)
+	}
+	if p, ok := CurrentPlayer(g); !ok || p != 0 {
+		t.Fatalf("expected the bidder to act, got %d", p)
+	}
+	if len(LegalActions(g, 1)) != 0 {
+		t.Fatalf("only the bidder may act in the raise step")
+	}
+	acts := LegalActions(g, 0)
+	if acts[0].Type != ActionPass {
+		t.Fatalf("expected keeping the contract to be offered first, got %+v", acts[0])
+	}
+	for _, a := range acts[1:] {
+		if a.Type != ActionRaiseContract || a.Bid <= 100 || (a.Bid-100)%r.BidStep != 0 {
+			t.Fatalf("unexpected raise offered: %+v", a)
+		}
+	}
+	if len(acts) < 2 {
+		t.Fatalf("expected raise actions after the kitty")
+	}
+	if err := ApplyAction(&g, 0, Action{Type: ActionRaiseContract, Bid: 100 + r.BidStep/2}); err == nil {
+		t.Fatalf("expected off-step raise to be rejected")
+	}
+	if err := ApplyAction(&g, 0, Action{Type: ActionRaiseContract, Bid: 120}); err != nil {
+		t.Fatalf("raise failed: %v", err)
+	}
+	if g.Round.BidValue != 120 || g.Round.Phase != PhaseSnos {
+		t.Fatalf("expected contract 120 in snos phase, got %d in %v", g.Round.BidValue, g.Round.Phase)
+	}
+	for _, a := range LegalActions(g, 0) {
+		if a.Type == ActionRaiseContract {
+			t.Fatalf("raise should only be offered once")
+		}
+	}
+}
+
+func TestRaiseContractKept(t *testing.T) {
+	g := newTestGame(t, RaisePreset(), 1)
+	g.Round.Phase = PhaseRaise
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 100
+
+	if err := ApplyAction(&g, 0, Action{Type: ActionPass}); err != nil {
+		t.Fatalf("keep failed: %v", err)
+	}
+	if g.Round.BidValue != 100 || g.Round.Phase != PhaseSnos {
+		t.Fatalf("expected contract 100 in snos phase, got %d in %v", g.Round.BidValue, g.Round.Phase)
+	}
+}
+
+func TestRaiseContractOffByDefault(t *testing.T) {
+	g := newTestGame(t, ClassicPreset(), 1)
+	g.Round.Phase = PhaseKittyTake
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 100
+
+	if err := ApplyAction(&g, 0, Action{Type: ActionTakeKitty}); err != nil {
+		t.Fatalf("take kitty failed: %v", err)
+	}
+	if g.Round.Phase != PhaseSnos {
+		t.Fatalf("expected snos right after the kitty, got %v", g.Round.Phase)
+	}
+	for _, a := range LegalActions(g, 0) {
+		if a.Type == ActionRaiseContract {
+			t.Fatalf("raise offered without AllowContractRaise")
+		}
+	}
+	if err := ApplyAction(&g, 0, Action{Type: ActionRaiseContract, Bid: 120}); err == nil {
+		t.Fatalf("expected raise to be rejected in the snos phase")
+	}
+}
+
+func TestKittyFaceUpRevealedWhenBiddingWon(t *testing.T) {
+	r := ClassicPreset()
+	r.KittyFaceUp = true
+	g := newTestGame(t, r, 1)
+	DealRound(&g)
+	kitty := append([]Card(nil), g.Round.Kitty...)
+
+	first := g.Round.BidTurn
+	if err := ApplyAction(&g, first, Action{Type: ActionBid, Bid: r.BidMin}); err != nil {
+		t.Fatalf("bid failed: %v", err)
+	}
+	for g.Round.Phase == PhaseBidding {
+		if err := ApplyAction(&g, g.Round.BidTurn, Action{Type: ActionPass}); err != nil {
+			t.Fatalf("pass failed: %v", err)
+		}
+	}
+	if g.Round.Phase != PhaseKittyTake {
+		t.Fatalf("expected kitty take phase, got %v", g.Round.Phase)
+	}
+	if len(g.Round.RevealedKitty) != len(kitty) {
+		t.Fatalf("expected revealed kitty of %d cards, got %d", len(kitty), len(g.Round.RevealedKitty))
+	}
+	for _, c := range kitty {
+		if !containsCard(g.Round.RevealedKitty, c) {
+			t.Fatalf("kitty card %v not revealed", c)
+		}
+	}
+	if err := ApplyAction(&g, first, Action{Type: ActionTakeKitty}); err != nil {
+		t.Fatalf("kitty take failed: %v", err)
+	}
+	if len(g.Round.RevealedKitty) != len(kitty) {
+		t.Fatalf("revealed kitty should stay visible after take")
+	}
+}
+
+func newTestGame(t *testing.T, r Rules, seed int64) GameState {
+	t.Helper()
+	g, err := NewGame(r, seed)
+	if err != nil {
+		t.Fatalf("new game: %v", err)
+	}
+	return g
+}
+
+func passAll(t *testing.T, g *GameState) {
+	t.Helper()
+	for g.Round.Phase == PhaseBidding {
+		if err := ApplyAction(g, g.Round.BidTurn, Action{Type: ActionPass}); err != nil {
+			t.Fatalf("pass failed: %v", err)
+		}
+	}
+}
+
+func TestAllPassRedealsByDefault(t *testing.T) {
+	g := newTestGame(t, ClassicPreset(), 1)
+	DealRound(&g)
+	passAll(t, &g)
+	if g.Round.Phase != PhaseDeal || g.Round.Dealer != 1 {
+		t.Fatalf("expected redeal by next dealer, got phase %v dealer %d", g.Round.Phase, g.Round.Dealer)
+	}
+}
+
+func TestAllPassForcedBid(t *testing.T) {
+	r := ClassicPreset()
+	r.AllPassPolicy = AllPassForcedBid
+	r.ForcedBidSeat = 1
+	r.ForcedBidValue = 100
+	g := newTestGame(t, r, 1)
+	g.Round.Dealer = 2
+	DealRound(&g)
+	passAll(t, &g)
+
+	if g.Round.Phase != PhaseKittyTake {
+		t.Fatalf("expected kitty take after forced bid, got %v", g.Round.Phase)
+	}
+	if g.Round.BidWinner != 0 || g.Round.BidValue != 100 || !g.Round.ForcedBid {
+		t.Fatalf("expected seat 0 forced to 100, got seat %d bid %d", g.Round.BidWinner, g.Round.BidValue)
+	}
+}
+
+func TestAllPassRaspasy(t *testing.T) {
+	r := ClassicPreset()
+	r.AllPassPolicy = AllPassRaspasy
+	r.RaspasyKitty = RaspasyKittyFirstTrick
+	g := newTestGame(t, r, 1)
+	DealRound(&g)
+	passAll(t, &g)
+
+	if g.Round.Phase != PhasePlayTricks || !g.Round.Raspasy {
+		t.Fatalf("expected raspasy play, got phase %v", g.Round.Phase)
+	}
+	if g.Round.BidWinner != -1 || g.Round.Leader != 1 {
+		t.Fatalf("expected no contract and leader left of dealer, got bidder %d leader %d", g.Round.BidWinner, g.Round.Leader)
+	}
+	for _, a := range LegalActions(g, g.Round.Leader) {
+		if a.Type == ActionRospis {
+			t.Fatalf("rospis should not be offered in raspasy")
+		}
+	}
+	for i := 0; i < r.Players; i++ {
+		p, _ := CurrentPlayer(g)
+		a := LegalActions(g, p)[0]
+		if err := ApplyAction(&g, p, a); err != nil {
+			t.Fatalf("play failed: %v", err)
+		}
+	}
+	if g.Round.KittyOwner < 0 || len(g.Players[g.Round.KittyOwner].Tricks) != 1 {
+		t.Fatalf("expected first trick winner to own the kitty, got %d", g.Round.KittyOwner)
+	}
+}
+
 func containsCard(hand []Card, card Card) bool {
 	for _, c := range hand {
 		if c == card {
@@ -118,7 +502,7 @@ func containsCard(hand []Card, card Card) bool {
 
 func TestMarriageDeclarationSetsTrump(t *testing.T) {
 	r := ClassicPreset()
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	g.Round.Phase = PhasePlayTricks
 	g.Round.Leader = 0
 	g.Round.DeclaredMarriages = make(map[int]map[Suit]bool)
@@ -143,9 +527,32 @@ func TestMarriageDeclarationSetsTrump(t *testing.T) {
 	}
 }
 
+func TestMarriageUsesRuleValues(t *testing.T) {
+	r := ClassicPreset()
+	r.MarriagePoints[SuitSpades] = 50
+	g := newTestGame(t, r, 1)
+	g.Round.Phase = PhasePlayTricks
+	g.Round.Leader = 0
+	g.Round.DeclaredMarriages = make(map[int]map[Suit]bool)
+	g.Players[0].Hand = []Card{
+		{Suit: SuitSpades, Rank: RankQ},
+		{Suit: SuitSpades, Rank: RankK},
+	}
+	g.Players[0].Tricks = [][]Card{{{Suit: SuitClubs, Rank: RankA}}}
+
+	card := g.Players[0].Hand[1]
+	suit := SuitSpades
+	if err := ApplyAction(&g, 0, Action{Type: ActionPlayCard, Card: &card, MarriageSuit: &suit}); err != nil {
+		t.Fatalf("marriage play failed: %v", err)
+	}
+	if g.Players[0].MarriagePts != 50 {
+		t.Fatalf("expected house-rule marriage value 50, got %d", g.Players[0].MarriagePts)
+	}
+}
+
 func TestMarriageRequiresTrick(t *testing.T) {
 	r := ClassicPreset()
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	g.Round.Phase = PhasePlayTricks
 	g.Round.Leader = 0
 	g.Round.DeclaredMarriages = make(map[int]map[Suit]bool)
@@ -162,9 +569,47 @@ func TestMarriageRequiresTrick(t *testing.T) {
 	}
 }
 
+func TestMarriageOnLeadOnly(t *testing.T) {
+	r := ClassicPreset()
+	g := newTestGame(t, r, 1)
+	g.Round.Phase = PhasePlayTricks
+	g.Round.Leader = 2
+	g.Round.TrickOrder = []int{2, 0, 1}
+	g.Round.TrickCards = []Card{{Suit: SuitHearts, Rank: Rank9}}
+	g.Round.DeclaredMarriages = make(map[int]map[Suit]bool)
+
+	g.Players[0].Hand = []Card{
+		{Suit: SuitHearts, Rank: RankQ},
+		{Suit: SuitHearts, Rank: RankK},
+	}
+	g.Players[0].Tricks = [][]Card{{{Suit: SuitClubs, Rank: RankA}}}
+
+	for _, a := range LegalActions(g, 0) {
+		if a.MarriageSuit != nil {
+			t.Fatalf("marriage should not be offered when following")
+		}
+	}
+	card := g.Players[0].Hand[0]
+	suit := SuitHearts
+	if err := ApplyAction(&g, 0, Action{Type: ActionPlayCard, Card: &card, MarriageSuit: &suit}); err == nil {
+		t.Fatalf("expected error when declaring marriage while following")
+	}
+
+	g.Rules.MarriageOnLeadOnly = false
+	offered := false
+	for _, a := range LegalActions(g, 0) {
+		if a.MarriageSuit != nil {
+			offered = true
+		}
+	}
+	if !offered {
+		t.Fatalf("expected marriage to be offered when the rule is off")
+	}
+}
+
 func TestRospisAdjustsScoresAndResetsRound(t *testing.T) {
 	r := ClassicPreset()
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	g.Round.Phase = PhasePlayTricks
 	g.Round.BidWinner = 0
 	g.Round.BidValue = 120
@@ -183,3 +628,266 @@ func TestRospisAdjustsScoresAndResetsRound(t *testing.T) {
 		t.Fatalf("expected round reset to deal")
 	}
 }
+
+func TestRospisPaymentRespectsDefenderCap(t *testing.T) {
+	r := ClassicPreset()
+	r.DefenderScoreCap = 800
+	g := newTestGame(t, r, 1)
+	g.Round.Phase = PhasePlayTricks
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 200
+	g.Players[1].GameScore = 790
+
+	if err := ApplyAction(&g, 0, Action{Type: ActionRospis}); err != nil {
+		t.Fatalf("rospis failed: %v", err)
+	}
+	if g.Players[1].GameScore != 800 || g.Players[2].GameScore != 100 {
+		t.Fatalf("expected defender 1 capped at 800 and defender 2 at 100, got %d/%d", g.Players[1].GameScore, g.Players[2].GameScore)
+	}
+	capped := g.LastRoundEffects.Capped
+	if len(capped) != 1 || capped[0] != (ScoreChange{Player: 1, Points: 90}) {
+		t.Fatalf("unexpected capped effects: %+v", capped)
+	}
+}
+
+func TestRospisPayoutCanEndTheGame(t *testing.T) {
+	r := ClassicPreset()
+	g := newTestGame(t, r, 1)
+	g.Round.Phase = PhasePlayTricks
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 120
+	g.Players[1].GameScore = 950
+
+	if err := ApplyAction(&g, 0, Action{Type: ActionRospis}); err != nil {
+		t.Fatalf("rospis failed: %v", err)
+	}
+	if g.Players[1].GameScore != 1010 {
+		t.Fatalf("expected defender paid up to 1010, got %d", g.Players[1].GameScore)
+	}
+	e := g.LastRoundEffects
+	if g.Round.Phase != PhaseGameOver || !e.HasWinner || e.Winner != 1 {
+		t.Fatalf("expected defender 1 to win through the rospis, got phase %v effects %+v", g.Round.Phase, e)
+	}
+	if len(g.History) != 1 || g.History[0].Outcome != OutcomeRospis || !g.History[0].Effects.HasWinner {
+		t.Fatalf("expected the winning rospis recorded, got %+v", g.History)
+	}
+}
+
+func TestRospisPayoutTakesDefenderOntoBarrel(t *testing.T) {
+	r := ClassicPreset()
+	g := newTestGame(t, r, 1)
+	g.Round.Phase = PhasePlayTricks
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 120
+	g.Players[2].GameScore = 830
+
+	if err := ApplyAction(&g, 0, Action{Type: ActionRospis}); err != nil {
+		t.Fatalf("rospis failed: %v", err)
+	}
+	if !g.Players[2].OnBarrel || g.Players[2].BarrelAttempts != 1 {
+		t.Fatalf("expected defender 2 on the barrel after one attempt, got %+v", g.Players[2])
+	}
+	if e := g.LastRoundEffects; len(e.BarrelEnter) != 1 || e.BarrelEnter[0] != 2 {
+		t.Fatalf("expected the barrel entry reported, got %+v", e)
+	}
+}
+
+func playToContra(t *testing.T, r Rules) GameState {
+	t.Helper()
+	g := newTestGame(t, r, 1)
+	DealRound(&g)
+	bidder := g.Round.BidTurn
+	if err := ApplyAction(&g, bidder, Action{Type: ActionBid, Bid: r.BidMin}); err != nil {
+		t.Fatalf("bid failed: %v", err)
+	}
+	passAll(t, &g)
+	if err := ApplyAction(&g, bidder, Action{Type: ActionTakeKitty}); err != nil {
+		t.Fatalf("take kitty failed: %v", err)
+	}
+	snos := append([]Card(nil), g.Players[bidder].Hand[:r.SnosCards]...)
+	if err := ApplyAction(&g, bidder, Action{Type: ActionSnos, Cards: snos}); err != nil {
+		t.Fatalf("snos failed: %v", err)
+	}
+	return g
+}
+
+func TestRejectedSnosLeavesHandsUntouched(t *testing.T) {
+	r := ClassicPreset()
+	g := newTestGame(t, r, 1)
+	DealRound(&g)
+	bidder := g.Round.BidTurn
+	if err := ApplyAction(&g, bidder, Action{Type: ActionBid, Bid: r.BidMin}); err != nil {
+		t.Fatalf("bid failed: %v", err)
+	}
+	passAll(t, &g)
+	if err := ApplyAction(&g, bidder, Action{Type: ActionTakeKitty}); err != nil {
+		t.Fatalf("take kitty failed: %v", err)
+	}
+	before := g.Clone()
+
+	// The second card is not in the bidder's hand.
+	opp := Opponents(g, bidder)[0]
+	bad := []Card{g.Players[bidder].Hand[0], g.Players[opp].Hand[0]}
+	if err := ApplyAction(&g, bidder, Action{Type: ActionSnos, Cards: bad}); err == nil {
+		t.Fatalf("expected a snos with a foreign card to be rejected")
+	}
+	// An opponent short of a card cannot be brought to PlayHandSize.
+	g.Players[opp].Hand = g.Players[opp].Hand[1:]
+	short := g.Players[opp].Hand
+	snos := append([]Card(nil), g.Players[bidder].Hand[:r.SnosCards]...)
+	if err := ApplyAction(&g, bidder, Action{Type: ActionSnos, Cards: snos}); err == nil {
+		t.Fatalf("expected a snos leaving uneven hands to be rejected")
+	}
+
+	hand, was := g.Players[bidder].Hand, before.Players[bidder].Hand
+	if len(hand) != len(was) {
+		t.Fatalf("rejected snos changed the bidder's hand: %v, was %v", hand, was)
+	}
+	for i := range was {
+		if hand[i] != was[i] {
+			t.Fatalf("rejected snos changed the bidder's hand: %v, was %v", hand, was)
+		}
+	}
+	if len(g.Players[opp].Hand) != len(short) || g.Round.Phase != PhaseSnos || len(g.Round.Record.Snos) != 0 {
+		t.Fatalf("rejected snos changed the round: phase %v, snos %v", g.Round.Phase, g.Round.Record.Snos)
+	}
+}
+
+func TestContraAndRecontra(t *testing.T) {
+	g := playToContra(t, KontraPreset())
+	bidder := g.Round.BidWinner
+	if g.Round.Phase != PhaseContra {
+		t.Fatalf("expected contra phase after snos, got %v", g.Round.Phase)
+	}
+	opponents := Opponents(g, bidder)
+	if p, _ := CurrentPlayer(g); p != opponents[0] {
+		t.Fatalf("expected first defender %d to speak, got %d", opponents[0], p)
+	}
+	if err := ApplyAction(&g, bidder, Action{Type: ActionRecontra}); err == nil {
+		t.Fatalf("recontra before a contra should be rejected")
+	}
+	if err := ApplyAction(&g, opponents[0], Action{Type: ActionPass}); err != nil {
+		t.Fatalf("defender pass failed: %v", err)
+	}
+	if err := ApplyAction(&g, opponents[1], Action{Type: ActionContra}); err != nil {
+		t.Fatalf("contra failed: %v", err)
+	}
+	if g.Round.Multiplier != 2 || g.Round.ContraBy != opponents[1] || g.Round.ContraTurn != bidder {
+		t.Fatalf("unexpected state after contra: %+v", g.Round)
+	}
+	if err := ApplyAction(&g, bidder, Action{Type: ActionRecontra}); err != nil {
+		t.Fatalf("recontra failed: %v", err)
+	}
+	if g.Round.Multiplier != 4 || g.Round.Phase != PhasePlayTricks || g.Round.Leader != bidder {
+		t.Fatalf("expected play at x4 led by bidder, got x%d in %v", g.Round.Multiplier, g.Round.Phase)
+	}
+}
+
+func TestContraAllDefendersPass(t *testing.T) {
+	g := playToContra(t, KontraPreset())
+	for _, p := range Opponents(g, g.Round.BidWinner) {
+		if err := ApplyAction(&g, p, Action{Type: ActionPass}); err != nil {
+			t.Fatalf("defender pass failed: %v", err)
+		}
+	}
+	if g.Round.Phase != PhasePlayTricks || g.Round.StakeMultiplier() != 1 {
+		t.Fatalf("expected undoubled play, got x%d in %v", g.Round.StakeMultiplier(), g.Round.Phase)
+	}
+}
+
+func TestContraSkippedWhenDisabled(t *testing.T) {
+	g := playToContra(t, ClassicPreset())
+	if g.Round.Phase != PhasePlayTricks {
+		t.Fatalf("expected play to start after snos, got %v", g.Round.Phase)
+	}
+}
+
+func TestRospisPolicies(t *testing.T) {
+	cases := []struct {
+		policy RospisPolicy
+		fixed  int
+		want   int
+	}{
+		{RospisHalfBid, 0, 42},
+		{RospisHalfRounded, 0, 40},
+		{RospisFixed, 50, 50},
+	}
+	for _, tc := range cases {
+		r := ClassicPreset()
+		r.RospisPolicy = tc.policy
+		r.RospisFixedPoints = tc.fixed
+		g := newTestGame(t, r, 1)
+		g.Round.Phase = PhasePlayTricks
+		g.Round.BidWinner = 0
+		g.Round.BidValue = 85
+
+		if err := ApplyAction(&g, 0, Action{Type: ActionRospis}); err != nil {
+			t.Fatalf("rospis failed: %v", err)
+		}
+		if g.Players[1].GameScore != tc.want || g.Players[2].GameScore != tc.want {
+			t.Fatalf("policy %v: expected +%d each, got %d/%d", tc.policy, tc.want, g.Players[1].GameScore, g.Players[2].GameScore)
+		}
+		want := []ScoreChange{{Player: 0, Points: -85}, {Player: 1, Points: tc.want}, {Player: 2, Points: tc.want}}
+		if len(g.LastRoundEffects.Rospis) != len(want) {
+			t.Fatalf("unexpected rospis effects: %+v", g.LastRoundEffects.Rospis)
+		}
+		for i := range want {
+			if g.LastRoundEffects.Rospis[i] != want[i] {
+				t.Fatalf("unexpected rospis effects: %+v", g.LastRoundEffects.Rospis)
+			}
+		}
+	}
+}
+
+func TestRospisRefusedByDefender(t *testing.T) {
+	r := ClassicPreset()
+	r.RospisRefusable = true
+	g := newTestGame(t, r, 1)
+	DealRound(&g)
+	g.Round.Phase = PhasePlayTricks
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 120
+	g.Round.Leader = 0
+
+	if err := ApplyAction(&g, 0, Action{Type: ActionRospis}); err != nil {
+		t.Fatalf("rospis failed: %v", err)
+	}
+	if g.Round.Phase != PhaseRospis || g.Round.RospisTurn != 1 {
+		t.Fatalf("expected defender 1 to answer the rospis, got %v turn %d", g.Round.Phase, g.Round.RospisTurn)
+	}
+	if err := ApplyAction(&g, 1, Action{Type: ActionPass}); err != nil {
+		t.Fatalf("accept failed: %v", err)
+	}
+	if err := ApplyAction(&g, 2, Action{Type: ActionRefuseRospis}); err != nil {
+		t.Fatalf("refuse failed: %v", err)
+	}
+	if g.Round.Phase != PhasePlayTricks || g.Players[0].GameScore != 0 {
+		t.Fatalf("expected play to resume without a settlement")
+	}
+	for _, a := range LegalActions(g, 0) {
+		if a.Type == ActionRospis {
+			t.Fatalf("rospis should not be offered again after a refusal")
+		}
+	}
+}
+
+func TestRospisAcceptedByAllDefenders(t *testing.T) {
+	r := ClassicPreset()
+	r.RospisRefusable = true
+	g := newTestGame(t, r, 1)
+	g.Round.Phase = PhasePlayTricks
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 120
+
+	if err := ApplyAction(&g, 0, Action{Type: ActionRospis}); err != nil {
+		t.Fatalf("rospis failed: %v", err)
+	}
+	for _, p := range []int{1, 2} {
+		if err := ApplyAction(&g, p, Action{Type: ActionPass}); err != nil {
+			t.Fatalf("accept by %d failed: %v", p, err)
+		}
+	}
+	if g.Round.Phase != PhaseDeal || g.Players[0].GameScore != -120 || g.Players[1].GameScore != 60 {
+		t.Fatalf("expected settled rospis, got %v with scores %d/%d", g.Round.Phase, g.Players[0].GameScore, g.Players[1].GameScore)
+	}
+}
@@ -1,10 +1,8 @@
 package engine
 
-import "math/rand"
-
 func BuildDeck(r Rules) []Card {
-	deck := make([]Card, 0, 24)
 	suits := []Suit{SuitClubs, SuitDiamonds, SuitHearts, SuitSpades}
+	deck := make([]Card, 0, len(suits)*len(r.DeckRanks))
 	for _, s := range suits {
 		for _, rank := range r.DeckRanks {
 			deck = append(deck, Card{Suit: s, Rank: rank})
@@ -13,41 +11,71 @@ func BuildDeck(r Rules) []Card {
 	return deck
 }
 
-func Shuffle(deck []Card, seed int64) []Card {
-	shuffled := make([]Card, len(deck))
-	copy(shuffled, deck)
-	rng := rand.New(rand.NewSource(seed))
-	rng.Shuffle(len(shuffled), func(i, j int) {
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-	})
-	return shuffled
+// RoundSeed mixes the game seed with the round number, so each deal gets
+// its own shuffle while the game stays reproducible from Seed.
+func RoundSeed(seed int64, round int) int64 {
+	state := uint64(seed) + uint64(round)*splitmixGamma
+	return int64(splitmix64(&state))
 }
 
 // DealRound deals cards into player hands and kitty based on rules.
-// It mutates game state deterministically based on seed.
+// Every deal, redeals included, advances g.RoundNumber and shuffles with
+// the shuffler's round seed for g.Seed and g.RoundNumber. Only a
+// ReplayableShuffler commits to its deal; other deals leave DealCommitment
+// empty.
 func DealRound(g *GameState) {
-	deck := Shuffle(BuildDeck(g.Rules), g.Seed)
-	players := g.Rules.Players
+	g.RoundNumber++
+	shuffler := g.Shuffler
+	if shuffler == nil {
+		shuffler = StableShuffler{}
+	}
+	seed := RoundSeed(g.Seed, g.RoundNumber)
+	replayable, ok := shuffler.(ReplayableShuffler)
+	if ok {
+		seed = replayable.RoundSeed(g.Seed, g.RoundNumber)
+	}
+	deck := shuffler.Shuffle(BuildDeck(g.Rules), seed)
+	g.Round.DealSeed = 0
+	g.Round.DealCommitment = ""
+	g.Round.DealDeck = nil
+	if ok {
+		g.Round.DealSeed = seed
+		g.Round.DealCommitment = DealCommitment(seed, deck)
+		g.Round.DealDeck = deck
+	}
 	handSize := g.Rules.DealHandSize
 	kittySize := g.Rules.KittySize
+	deadSize := g.Rules.DeadHandSize
 
-	if handSize*players+kittySize != len(deck) {
+	if handSize*g.Rules.ActivePlayers()+kittySize+deadSize != len(deck) {
 		panic("invalid deal configuration: does not exhaust deck")
 	}
 
 	idx := 0
-	for p := 0; p < players; p++ {
+	for _, p := range activeSeats(*g) {
 		g.Players[p].Hand = append([]Card(nil), deck[idx:idx+handSize]...)
 		idx += handSize
 	}
 	g.Round.Kitty = append([]Card(nil), deck[idx:idx+kittySize]...)
+	idx += kittySize
+	g.Round.DeadHand = append([]Card(nil), deck[idx:idx+deadSize]...)
+	g.Round.DealerPts = 0
+	if g.Rules.DealerSitsOut && g.Rules.DealerScoring == DealerScoresKitty {
+		g.Round.DealerPts = handPoints(g.Rules, g.Round.Kitty)
+	}
 	g.Round.HandsDealt = true
 	g.Round.Phase = PhaseBidding
 	g.Round.Bids = make(map[int]int)
 	g.Round.Passed = make(map[int]bool)
-	g.Round.BidTurn = (g.Round.Dealer + 1) % players
+	g.Round.BidTurn = nextSeat(*g, g.Round.Dealer)
 	g.Round.BidWinner = -1
 	g.Round.BidValue = 0
+	g.Round.KittyOwner = -1
+	g.Round.Multiplier = 1
+	g.Round.ContraBy = -1
+	g.Round.ContraTurn = -1
+	g.Round.RospisTurn = -1
 	g.Round.DeclaredMarriages = make(map[int]map[Suit]bool)
 	g.Round.DeclaredAceMarriage = make(map[int]bool)
+	startRecord(g)
 }
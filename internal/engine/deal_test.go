@@ -4,8 +4,8 @@ import "testing"
 
 func TestDealDeterministic(t *testing.T) {
 	r := ClassicPreset()
-	g1 := NewGame(r, 42)
-	g2 := NewGame(r, 42)
+	g1 := newTestGame(t, r, 42)
+	g2 := newTestGame(t, r, 42)
 
 	DealRound(&g1)
 	DealRound(&g2)
@@ -34,7 +34,7 @@ func TestDealDeterministic(t *testing.T) {
 
 func TestDealExhaustsDeck(t *testing.T) {
 	r := ClassicPreset()
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	DealRound(&g)
 
 	seen := map[Card]bool{}
@@ -56,3 +56,121 @@ func TestDealExhaustsDeck(t *testing.T) {
 		t.Fatalf("deck not exhausted: got %d", len(seen))
 	}
 }
+
+func TestDealFourPlayersDealerSitsOut(t *testing.T) {
+	r := FourPlayerPreset()
+	g := newTestGame(t, r, 7)
+	g.Round.Dealer = 2
+	DealRound(&g)
+
+	for i, p := range g.Players {
+		if i == 2 {
+			if len(p.Hand) != 0 {
+				t.Fatalf("dealer should get no cards, got %d", len(p.Hand))
+			}
+			continue
+		}
+		if len(p.Hand) != r.DealHandSize {
+			t.Fatalf("player %d hand size: got %d", i, len(p.Hand))
+		}
+	}
+	if g.Round.BidTurn != 3 {
+		t.Fatalf("expected bidding to start left of dealer, got %d", g.Round.BidTurn)
+	}
+	kittyPts := 0
+	for _, c := range g.Round.Kitty {
+		kittyPts += r.CardValue(c.Rank)
+	}
+	if g.Round.DealerPts != kittyPts {
+		t.Fatalf("expected dealer points %d, got %d", kittyPts, g.Round.DealerPts)
+	}
+}
+
+func TestDealTwoPlayersWithDeadHand(t *testing.T) {
+	r := TwoPlayerPreset()
+	g := newTestGame(t, r, 5)
+	DealRound(&g)
+
+	for i, p := range g.Players {
+		if len(p.Hand) != r.DealHandSize {
+			t.Fatalf("player %d hand size: got %d", i, len(p.Hand))
+		}
+	}
+	if len(g.Round.Kitty) != r.KittySize {
+		t.Fatalf("kitty size: got %d", len(g.Round.Kitty))
+	}
+	if len(g.Round.DeadHand) != r.DeadHandSize {
+		t.Fatalf("dead hand size: got %d", len(g.Round.DeadHand))
+	}
+}
+
+func TestCloneIsDeep(t *testing.T) {
+	g := newTestGame(t, ClassicPreset(), 3)
+	DealRound(&g)
+	snapshot := g.Clone()
+	hand := append([]Card(nil), g.Players[0].Hand...)
+
+	g.Players[0].Hand[0] = Card{Suit: SuitSpades, Rank: RankA}
+	g.Players[1].Tricks = append(g.Players[1].Tricks, []Card{hand[0]})
+	g.Round.Passed[1] = true
+	g.Round.Record.Bids = append(g.Round.Record.Bids, BidRecord{Player: 1, Pass: true})
+	g.ResetRound()
+
+	if len(snapshot.Players[0].Hand) != len(hand) || snapshot.Players[0].Hand[0] != hand[0] {
+		t.Fatalf("snapshot hand changed with the original")
+	}
+	if snapshot.Round.Passed[1] {
+		t.Fatalf("snapshot round maps changed with the original")
+	}
+	if len(snapshot.Players[1].Tricks) != 0 || len(snapshot.Round.Record.Bids) != 0 {
+		t.Fatalf("snapshot tricks or round record changed with the original")
+	}
+}
+
+func TestDealThirtyTwoCardDeck(t *testing.T) {
+	r := ThirtyTwoCardPreset()
+	if n := len(BuildDeck(r)); n != 32 {
+		t.Fatalf("expected 32-card deck, got %d", n)
+	}
+	g := newTestGame(t, r, 9)
+	DealRound(&g)
+	for i, p := range g.Players {
+		if len(p.Hand) != r.DealHandSize {
+			t.Fatalf("player %d hand size: got %d", i, len(p.Hand))
+		}
+	}
+	if len(g.Round.Kitty) != r.KittySize {
+		t.Fatalf("kitty size: got %d", len(g.Round.Kitty))
+	}
+}
+
+func TestEachDealGetsItsOwnShuffle(t *testing.T) {
+	r := ClassicPreset()
+	g := newTestGame(t, r, 42)
+	DealRound(&g)
+	first := append([]Card(nil), g.Players[0].Hand...)
+	g.ResetRound()
+	DealRound(&g)
+	if g.RoundNumber != 2 {
+		t.Fatalf("expected round number 2, got %d", g.RoundNumber)
+	}
+	same := true
+	for i, c := range g.Players[0].Hand {
+		if first[i] != c {
+			same = false
+		}
+	}
+	if same {
+		t.Fatalf("redeal produced the same hand")
+	}
+
+	replay := newTestGame(t, r, 42)
+	DealRound(&replay)
+	replay.ResetRound()
+	DealRound(&replay)
+	for i, c := range g.Players[0].Hand {
+		if replay.Players[0].Hand[i] != c {
+			t.Fatalf("second deal not reproducible from the game seed")
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DealCommitment hashes a round seed together with the deck order it
+// produced. The hash is published when the cards are dealt and the seed at
+// the end of the round, so players can check the deck was never swapped.
+//
+// The hashed message is the decimal seed, a colon and the cards joined by
+// single spaces, e.g. "42:9C QS KH ...", so any client can recompute it.
+func DealCommitment(seed int64, deck []Card) string {
+	cards := make([]string, 0, len(deck))
+	for _, c := range deck {
+		cards = append(cards, c.String())
+	}
+	sum := sha256.Sum256([]byte(strconv.FormatInt(seed, 10) + ":" + strings.Join(cards, " ")))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyDeal replays the shuffle for a revealed round seed and checks it
+// against the commitment published at the deal. It returns the deck order
+// so callers can also compare it with the cards they saw. A nil shuffler
+// means the default StableShuffler; only a ReplayableShuffler can be
+// verified.
+func VerifyDeal(r Rules, shuffler Shuffler, seed int64, commitment string) ([]Card, error) {
+	if shuffler == nil {
+		shuffler = StableShuffler{}
+	}
+	if _, ok := shuffler.(ReplayableShuffler); !ok {
+		return nil, fmt.Errorf("%s shuffler cannot replay its deals", shuffler.Name())
+	}
+	deck := shuffler.Shuffle(BuildDeck(r), seed)
+	if DealCommitment(seed, deck) != commitment {
+		return nil, errors.New("deal does not match commitment")
+	}
+	return deck, nil
+}
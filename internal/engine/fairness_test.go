@@ -0,0 +1,59 @@
+package engine
+
+import "testing"
+
+func TestDealCommitmentGolden(t *testing.T) {
+	g := newTestGame(t, ClassicPreset(), 7)
+	g.Shuffler = StableShufflerV2{}
+	DealRound(&g)
+	if g.Round.DealSeed != 5760544504419808236 {
+		t.Fatalf("unexpected round seed %d", g.Round.DealSeed)
+	}
+	want := "7d6eeab05f54e55a06060dc7a430c1ffd8d26995e607775b3506c08074d045c8"
+	if g.Round.DealCommitment != want {
+		t.Fatalf("unexpected commitment %s", g.Round.DealCommitment)
+	}
+}
+
+func TestVerifyDeal(t *testing.T) {
+	r := ClassicPreset()
+	g := newTestGame(t, r, 99)
+	DealRound(&g)
+
+	deck, err := VerifyDeal(r, nil, g.Round.DealSeed, g.Round.DealCommitment)
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	for i, c := range g.Players[0].Hand {
+		if deck[i] != c {
+			t.Fatalf("replayed deck does not match the dealt hand")
+		}
+	}
+	if len(g.Round.DealDeck) != len(deck) {
+		t.Fatalf("expected the dealt deck kept for the reveal, got %v", g.Round.DealDeck)
+	}
+	for i, c := range g.Round.DealDeck {
+		if deck[i] != c {
+			t.Fatalf("replayed deck does not match the kept deck")
+		}
+	}
+	if _, err := VerifyDeal(r, nil, g.Round.DealSeed+1, g.Round.DealCommitment); err == nil {
+		t.Fatalf("expected a wrong seed to fail verification")
+	}
+}
+
+func TestCryptoDealsAreNotCommitted(t *testing.T) {
+	r := ClassicPreset()
+	g := newTestGame(t, r, 3)
+	DealRound(&g)
+	seed, commitment := g.Round.DealSeed, g.Round.DealCommitment
+
+	g.Shuffler = CryptoShuffler{}
+	DealRound(&g)
+	if g.Round.DealCommitment != "" || g.Round.DealSeed != 0 || g.Round.DealDeck != nil {
+		t.Fatalf("crypto deal should not be committed, got %q", g.Round.DealCommitment)
+	}
+	if _, err := VerifyDeal(r, CryptoShuffler{}, seed, commitment); err == nil {
+		t.Fatalf("expected a crypto deal to be unverifiable")
+	}
+}
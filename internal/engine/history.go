@@ -0,0 +1,114 @@
+package engine
+
+// RoundOutcome says how a deal ended.
+type RoundOutcome int
+
+const (
+	// OutcomeScored means the hand was played out and scored.
+	OutcomeScored RoundOutcome = iota
+	// OutcomeRospis means the bidder conceded the contract.
+	OutcomeRospis
+	// OutcomeAllPassed means everyone passed and the deal moved on.
+	OutcomeAllPassed
+	// OutcomeRedealRequested means a player asked for a redeal.
+	OutcomeRedealRequested
+)
+
+// BidRecord is one call in the auction.
+type BidRecord struct {
+	Player int
+	Bid    int
+	Pass   bool
+}
+
+// Play is one card put on a trick.
+type Play struct {
+	Player int
+	Card   Card
+}
+
+// TrickRecord is a finished trick in play order.
+type TrickRecord struct {
+	Plays  []Play
+	Winner int
+}
+
+// MarriageRecord is a declared marriage; Ace marks the four-ace marriage,
+// which has no suit.
+type MarriageRecord struct {
+	Player int
+	Suit   Suit
+	Ace    bool
+	Points int
+}
+
+// RoundSummary records one deal, from the cards dealt to the scores it
+// left behind. Summaries are appended to GameState.History and never
+// changed afterwards.
+type RoundSummary struct {
+	Number        int
+	Dealer        int
+	Outcome       RoundOutcome
+	Bids          []BidRecord
+	Contract      int
+	ContractValue int
+	Multiplier    int
+	ForcedBid     bool
+	Raspasy       bool
+	Trump         *Suit
+	Kitty         []Card
+	Snos          []Card
+	Tricks        []TrickRecord
+	Marriages     []MarriageRecord
+	Points        []int
+	Effects       RoundEffects
+	Scores        []int
+}
+
+// startRecord opens the summary for a freshly dealt round.
+func startRecord(g *GameState) {
+	g.Round.Record = RoundSummary{
+		Number:   g.RoundNumber,
+		Dealer:   g.Round.Dealer,
+		Contract: -1,
+		Kitty:    append([]Card(nil), g.Round.Kitty...),
+	}
+}
+
+// recordRound closes the current round's summary and appends it to the
+// history. It must run before ResetRound wipes the round.
+func recordRound(g *GameState, outcome RoundOutcome) {
+	rec := g.Round.Record
+	rec.Outcome = outcome
+	rec.Contract = g.Round.BidWinner
+	rec.ContractValue = g.Round.BidValue
+	rec.Multiplier = g.Round.StakeMultiplier()
+	rec.ForcedBid = g.Round.ForcedBid
+	rec.Raspasy = g.Round.Raspasy
+	if g.Round.Trump != nil {
+		trump := *g.Round.Trump
+		rec.Trump = &trump
+	}
+	switch outcome {
+	case OutcomeScored:
+		rec.Points = append([]int(nil), g.LastRoundPoints...)
+		rec.Effects = g.LastRoundEffects
+	case OutcomeRospis:
+		rec.Effects = g.LastRoundEffects
+	}
+	rec.Scores = make([]int, len(g.Players))
+	for i, p := range g.Players {
+		rec.Scores[i] = p.GameScore
+	}
+	g.History = append(g.History, rec)
+}
+
+func (r RoundSummary) clone() RoundSummary {
+	out := r
+	out.Bids = append([]BidRecord(nil), r.Bids...)
+	out.Kitty = append([]Card(nil), r.Kitty...)
+	out.Snos = append([]Card(nil), r.Snos...)
+	out.Tricks = append([]TrickRecord(nil), r.Tricks...)
+	out.Marriages = append([]MarriageRecord(nil), r.Marriages...)
+	return out
+}
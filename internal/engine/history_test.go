@@ -0,0 +1,97 @@
+package engine
+
+import "testing"
+
+func TestHistoryRecordsPlayedRound(t *testing.T) {
+	r := ClassicPreset()
+	g := playToContra(t, r)
+	bidder := g.Round.BidWinner
+	kitty := append([]Card(nil), g.Round.Record.Kitty...)
+	for g.Round.Phase == PhasePlayTricks {
+		p, _ := CurrentPlayer(g)
+		var play Action
+		for _, a := range LegalActions(g, p) {
+			if a.Type == ActionPlayCard {
+				play = a
+				break
+			}
+		}
+		if err := ApplyAction(&g, p, play); err != nil {
+			t.Fatalf("play failed: %v", err)
+		}
+	}
+
+	if len(g.History) != 1 {
+		t.Fatalf("expected one recorded round, got %d", len(g.History))
+	}
+	rec := g.History[0]
+	if rec.Outcome != OutcomeScored || rec.Number != 1 || rec.Dealer != 0 {
+		t.Fatalf("unexpected summary header: %+v", rec)
+	}
+	if rec.Bids[0] != (BidRecord{Player: bidder, Bid: r.BidMin}) || len(rec.Bids) != r.Players {
+		t.Fatalf("unexpected bids: %+v", rec.Bids)
+	}
+	if rec.Contract != bidder || rec.ContractValue != r.BidMin || rec.Multiplier != 1 {
+		t.Fatalf("unexpected contract: seat %d value %d x%d", rec.Contract, rec.ContractValue, rec.Multiplier)
+	}
+	if len(rec.Kitty) != len(kitty) || len(rec.Snos) != r.SnosCards {
+		t.Fatalf("expected kitty and snos recorded, got %v / %v", rec.Kitty, rec.Snos)
+	}
+	if len(rec.Tricks) != r.PlayHandSize {
+		t.Fatalf("expected %d tricks, got %d", r.PlayHandSize, len(rec.Tricks))
+	}
+	for _, trick := range rec.Tricks {
+		if len(trick.Plays) != r.Players {
+			t.Fatalf("expected a card from every player, got %+v", trick.Plays)
+		}
+	}
+	for i, p := range g.Players {
+		if rec.Scores[i] != p.GameScore || rec.Points[i] != g.LastRoundPoints[i] {
+			t.Fatalf("seat %d: recorded %d/%d, state %d/%d", i, rec.Points[i], rec.Scores[i], g.LastRoundPoints[i], p.GameScore)
+		}
+	}
+}
+
+func TestHistoryRecordsAllPass(t *testing.T) {
+	g := newTestGame(t, ClassicPreset(), 1)
+	DealRound(&g)
+	passAll(t, &g)
+	DealRound(&g)
+	passAll(t, &g)
+
+	if len(g.History) != 2 {
+		t.Fatalf("expected two recorded rounds, got %d", len(g.History))
+	}
+	for i, rec := range g.History {
+		if rec.Outcome != OutcomeAllPassed || rec.Number != i+1 || rec.Dealer != i || rec.Contract != -1 {
+			t.Fatalf("unexpected summary %d: %+v", i, rec)
+		}
+		for _, b := range rec.Bids {
+			if !b.Pass {
+				t.Fatalf("expected only passes, got %+v", rec.Bids)
+			}
+		}
+	}
+}
+
+func TestHistoryRecordsRospis(t *testing.T) {
+	r := ClassicPreset()
+	g := newTestGame(t, r, 1)
+	g.Round.Phase = PhasePlayTricks
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 120
+
+	if err := ApplyAction(&g, 0, Action{Type: ActionRospis}); err != nil {
+		t.Fatalf("rospis failed: %v", err)
+	}
+	if len(g.History) != 1 {
+		t.Fatalf("expected one recorded round, got %d", len(g.History))
+	}
+	rec := g.History[0]
+	if rec.Outcome != OutcomeRospis || rec.Contract != 0 || rec.ContractValue != 120 {
+		t.Fatalf("unexpected summary: %+v", rec)
+	}
+	if len(rec.Effects.Rospis) != r.Players || rec.Scores[0] != -120 || rec.Scores[1] != 60 {
+		t.Fatalf("unexpected settlement: %+v scores %v", rec.Effects.Rospis, rec.Scores)
+	}
+}
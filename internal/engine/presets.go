@@ -0,0 +1,41 @@
+package engine
+
+// DefaultPreset names the ruleset used when a client does not pick one.
+const DefaultPreset = "tisyacha"
+
+// Preset is a named ruleset clients can choose when starting a game.
+type Preset struct {
+	Name        string
+	Description string
+	Rules       func() Rules
+}
+
+var presets = []Preset{
+	{Name: "tisyacha", Description: "Классическая «Тысяча» на троих", Rules: TisyachaPreset},
+	{Name: "strict_trumping", Description: "Обязательно козырять и перебивать козырь", Rules: StrictTrumpingPreset},
+	{Name: "raise", Description: "Заказчик может повысить ставку после прикупа", Rules: RaisePreset},
+	{Name: "kontra", Description: "Контра и реконтра после сноса", Rules: KontraPreset},
+	{Name: "four_player", Description: "Четверо игроков, сдающий не играет и получает очки прикупа", Rules: FourPlayerPreset},
+	{Name: "thirty_two", Description: "Колода из 32 карт с семёрками и восьмёрками на четверых", Rules: ThirtyTwoCardPreset},
+	{Name: "two_player", Description: "Двое игроков и лишняя рука для сноса", Rules: TwoPlayerPreset},
+}
+
+// Presets lists the registered rulesets in display order.
+func Presets() []Preset {
+	return append([]Preset(nil), presets...)
+}
+
+// LookupPreset returns a fresh copy of the rules registered under name.
+// "classic" is kept as an alias of the default ruleset and an empty name
+// selects the default.
+func LookupPreset(name string) (Rules, bool) {
+	if name == "" || name == "classic" {
+		name = DefaultPreset
+	}
+	for _, p := range presets {
+		if p.Name == name {
+			return p.Rules(), true
+		}
+	}
+	return Rules{}, false
+}
@@ -3,36 +3,21 @@ package engine
 func rankStrength(r Rank) int {
 	switch r {
 	case RankA:
-		return 6
+		return 8
 	case Rank10:
-		return 5
+		return 7
 	case RankK:
-		return 4
+		return 6
 	case RankQ:
-		return 3
+		return 5
 	case RankJ:
-		return 2
-	case Rank9:
-		return 1
-	default:
-		return 0
-	}
-}
-
-func cardPoints(r Rank) int {
-	switch r {
-	case RankA:
-		return 11
-	case Rank10:
-		return 10
-	case RankK:
 		return 4
-	case RankQ:
+	case Rank9:
 		return 3
-	case RankJ:
+	case Rank8:
 		return 2
-	case Rank9:
-		return 0
+	case Rank7:
+		return 1
 	default:
 		return 0
 	}
@@ -43,26 +28,6 @@ func RankStrength(r Rank) int {
 	return rankStrength(r)
 }
 
-// CardPoints exposes point value for a rank.
-func CardPoints(r Rank) int {
-	return cardPoints(r)
-}
-
-func marriageValue(s Suit) int {
-	switch s {
-	case SuitHearts:
-		return 100
-	case SuitDiamonds:
-		return 80
-	case SuitClubs:
-		return 60
-	case SuitSpades:
-		return 40
-	default:
-		return 0
-	}
-}
-
 func trickWinner(order []int, cards []Card, trump *Suit) int {
 	if len(order) == 0 || len(cards) == 0 {
 		return -1
@@ -97,39 +62,159 @@ func trickWinner(order []int, cards []Card, trump *Suit) int {
 	return order[bestIdx]
 }
 
+func roundPoints(mode PointsRounding, pts int) int {
+	rem := pts % 5
+	if rem < 0 {
+		rem += 5
+	}
+	switch mode {
+	case RoundingNearest5:
+		if rem >= 3 {
+			return pts - rem + 5
+		}
+		return pts - rem
+	case RoundingCeil5:
+		if rem > 0 {
+			return pts - rem + 5
+		}
+		return pts
+	default:
+		return pts
+	}
+}
+
+// creditDefender adds gain to a defender's score without taking them past
+// DefenderScoreCap, records any points cut off and returns what was added.
+func creditDefender(g *GameState, player, gain int) int {
+	if limit := g.Rules.DefenderScoreCap; limit > 0 && gain > 0 {
+		room := limit - g.Players[player].GameScore
+		if room < 0 {
+			room = 0
+		}
+		if gain > room {
+			g.LastRoundEffects.Capped = append(g.LastRoundEffects.Capped, ScoreChange{Player: player, Points: gain - room})
+			gain = room
+		}
+	}
+	g.Players[player].GameScore += gain
+	return gain
+}
+
+// wasCapped reports whether the player's gain was cut by DefenderScoreCap
+// this round.
+func wasCapped(g *GameState, player int) bool {
+	for _, c := range g.LastRoundEffects.Capped {
+		if c.Player == player {
+			return true
+		}
+	}
+	return false
+}
+
+func leaveBarrel(g *GameState, player int) {
+	g.Players[player].OnBarrel = false
+	g.Players[player].BarrelAttempts = 0
+	g.LastRoundEffects.BarrelExit = append(g.LastRoundEffects.BarrelExit, player)
+}
+
+// pickWinner applies the tiebreak policy to the players who reached the win
+// score off the barrel. It returns WinNone when the game goes on.
+func pickWinner(g *GameState, contract int) (int, WinReason) {
+	var contenders []int
+	for i, p := range g.Players {
+		if p.GameScore >= g.Rules.WinScore && !p.OnBarrel {
+			contenders = append(contenders, i)
+		}
+	}
+	switch len(contenders) {
+	case 0:
+		return -1, WinNone
+	case 1:
+		return contenders[0], WinSole
+	}
+	g.LastRoundEffects.Contenders = contenders
+
+	hasContract := func(players []int) bool {
+		for _, p := range players {
+			if p == contract {
+				return true
+			}
+		}
+		return false
+	}
+	if g.Rules.TiebreakPolicy == TiebreakBidderFirst && contract >= 0 && hasContract(contenders) {
+		return contract, WinBidder
+	}
+	var top []int
+	for _, p := range contenders {
+		switch {
+		case len(top) == 0 || g.Players[p].GameScore > g.Players[top[0]].GameScore:
+			top = []int{p}
+		case g.Players[p].GameScore == g.Players[top[0]].GameScore:
+			top = append(top, p)
+		}
+	}
+	if len(top) == 1 {
+		return top[0], WinHighestScore
+	}
+	if g.Rules.TiebreakPolicy == TiebreakPlayOn {
+		return -1, WinNone
+	}
+	if contract >= 0 && hasContract(top) {
+		return contract, WinBidder
+	}
+	return top[0], WinSeatOrder
+}
+
 func scoreRound(g *GameState) {
 	g.LastRoundEffects = RoundEffects{}
 	g.LastRoundEffects.Winner = -1
 	g.LastRoundPoints = make([]int, len(g.Players))
+	// A raspasy kitty claimed by the first trick is scored by its owner
+	// only, not again by a dealer who collects the kitty.
+	dealerPts := g.Round.DealerPts
+	if g.Round.Raspasy && g.Round.KittyOwner >= 0 {
+		dealerPts = 0
+	}
 	for i := range g.Players {
 		g.Players[i].RoundPts = 0
 		for _, trick := range g.Players[i].Tricks {
 			for _, c := range trick {
-				g.Players[i].RoundPts += cardPoints(c.Rank)
+				g.Players[i].RoundPts += g.Rules.CardValue(c.Rank)
 			}
 		}
 		g.Players[i].RoundPts += g.Players[i].MarriagePts
+		if !InRound(*g, i) {
+			g.Players[i].RoundPts += dealerPts
+		}
+		if g.Round.Raspasy && i == g.Round.KittyOwner {
+			g.Players[i].RoundPts += handPoints(g.Rules, g.Round.Kitty)
+		}
+		g.Players[i].RoundPts = roundPoints(g.Rules.PointsRounding, g.Players[i].RoundPts)
 		g.LastRoundPoints[i] = g.Players[i].RoundPts
 	}
 
 	contract := g.Round.BidWinner
+	contractMade := false
 	if contract >= 0 {
 		if g.Round.BidValue == 0 && g.Round.Bids != nil {
 			if v, ok := g.Round.Bids[contract]; ok {
 				g.Round.BidValue = v
 			}
 		}
+		mult := g.Round.StakeMultiplier()
 		if g.Players[contract].RoundPts >= g.Round.BidValue {
+			contractMade = true
 			if g.Rules.ContractScoresAsBid {
-				g.Players[contract].GameScore += g.Round.BidValue
+				g.Players[contract].GameScore += g.Round.BidValue * mult
 			} else {
-				g.Players[contract].GameScore += g.Players[contract].RoundPts
+				g.Players[contract].GameScore += g.Players[contract].RoundPts * mult
 			}
 		} else {
 			if g.Rules.ContractFailPenaltyBid {
-				g.Players[contract].GameScore -= g.Round.BidValue
+				g.Players[contract].GameScore -= g.Round.BidValue * mult
 			} else {
-				g.Players[contract].GameScore -= g.Players[contract].RoundPts
+				g.Players[contract].GameScore -= g.Players[contract].RoundPts * mult
 			}
 		}
 	}
@@ -138,12 +223,13 @@ func scoreRound(g *GameState) {
 		if i == contract {
 			continue
 		}
-		g.Players[i].GameScore += g.Players[i].RoundPts
+		// Defenders cannot climb past the cap on opponents' points alone.
+		creditDefender(g, i, g.Players[i].RoundPts)
 	}
 
 	// Bolts
 	for i := range g.Players {
-		if len(g.Players[i].Tricks) == 0 {
+		if len(g.Players[i].Tricks) == 0 && InRound(*g, i) {
 			g.Players[i].Bolts++
 			g.LastRoundEffects.Bolts = append(g.LastRoundEffects.Bolts, i)
 			if g.Players[i].Bolts >= g.Rules.BoltEvery {
@@ -154,47 +240,64 @@ func scoreRound(g *GameState) {
 		}
 	}
 
-	// Barrel handling
+	finishRound(g, contract, contractMade, g.LastRoundPoints, OutcomeScored)
+}
+
+// finishRound runs the steps shared by every scored deal, played out or
+// settled by a rospis: barrel moves, the dump, the winner check and the
+// history record. gains holds what each player earned toward a barrel
+// target this round.
+func finishRound(g *GameState, contract int, contractMade bool, gains []int, outcome RoundOutcome) {
+	// Barrel handling: newcomers climb on first and the policy decides what
+	// happens to anyone already sitting there. Everyone left on the barrel,
+	// newcomers included, then makes the target or uses up an attempt.
 	prevBarrel := make([]bool, len(g.Players))
-	barrelOwner := -1
 	for i := range g.Players {
 		prevBarrel[i] = g.Players[i].OnBarrel
-		if g.Players[i].OnBarrel {
-			barrelOwner = i
-			break
-		}
 	}
 	for i := range g.Players {
-		if g.Players[i].GameScore >= g.Rules.BarrelThreshold && g.Players[i].GameScore < g.Rules.WinScore && i != barrelOwner {
-			if barrelOwner >= 0 {
-				g.Players[barrelOwner].OnBarrel = false
-				g.Players[barrelOwner].BarrelAttempts = 0
-			}
-			g.Players[i].OnBarrel = true
-			g.Players[i].BarrelAttempts = 0
-			barrelOwner = i
+		// A player who started the round on the barrel still sits there or
+		// was just pushed off; either way they do not climb on again now.
+		if prevBarrel[i] || g.Players[i].OnBarrel || g.Players[i].GameScore < g.Rules.BarrelThreshold || g.Players[i].GameScore >= g.Rules.WinScore {
+			continue
 		}
-	}
-	if barrelOwner >= 0 {
-		if g.Players[barrelOwner].RoundPts >= g.Rules.BarrelTarget {
-			g.Players[barrelOwner].OnBarrel = false
-			g.Players[barrelOwner].BarrelAttempts = 0
-		} else {
-			g.Players[barrelOwner].BarrelAttempts++
-			if g.Players[barrelOwner].BarrelAttempts >= g.Rules.BarrelAttempts {
-				g.Players[barrelOwner].GameScore -= g.Rules.BoltPenalty
-				g.Players[barrelOwner].OnBarrel = false
-				g.Players[barrelOwner].BarrelAttempts = 0
-				g.LastRoundEffects.BarrelPenalty = append(g.LastRoundEffects.BarrelPenalty, barrelOwner)
+		if g.Rules.BarrelNeedsContract && !(i == contract && contractMade) {
+			// Only a successful contract takes a player onto the barrel.
+			continue
+		}
+		if wasCapped(g, i) {
+			// A defender held at the cap waits there, off the barrel.
+			continue
+		}
+		if g.Rules.BarrelPolicy != BarrelShared {
+			for j := range g.Players {
+				if !g.Players[j].OnBarrel {
+					continue
+				}
+				leaveBarrel(g, j)
+				if g.Rules.BarrelPolicy == BarrelPushOffPenalty {
+					g.Players[j].GameScore -= g.Rules.BarrelPushPenalty
+				}
+				g.LastRoundEffects.BarrelPushed = append(g.LastRoundEffects.BarrelPushed, j)
 			}
 		}
+		g.Players[i].OnBarrel = true
+		g.Players[i].BarrelAttempts = 0
+		g.LastRoundEffects.BarrelEnter = append(g.LastRoundEffects.BarrelEnter, i)
 	}
 	for i := range g.Players {
-		if !prevBarrel[i] && g.Players[i].OnBarrel {
-			g.LastRoundEffects.BarrelEnter = append(g.LastRoundEffects.BarrelEnter, i)
+		if !g.Players[i].OnBarrel || !InRound(*g, i) {
+			continue
+		}
+		if gains[i] >= g.Rules.BarrelTarget {
+			leaveBarrel(g, i)
+			continue
 		}
-		if prevBarrel[i] && !g.Players[i].OnBarrel {
-			g.LastRoundEffects.BarrelExit = append(g.LastRoundEffects.BarrelExit, i)
+		g.Players[i].BarrelAttempts++
+		if g.Players[i].BarrelAttempts >= g.Rules.BarrelAttempts {
+			g.Players[i].GameScore -= g.Rules.BoltPenalty
+			leaveBarrel(g, i)
+			g.LastRoundEffects.BarrelPenalty = append(g.LastRoundEffects.BarrelPenalty, i)
 		}
 	}
 
@@ -206,13 +309,16 @@ func scoreRound(g *GameState) {
 		}
 	}
 
-	for i, p := range g.Players {
-		if p.GameScore >= g.Rules.WinScore && !p.OnBarrel {
-			g.Round.Phase = PhaseGameOver
-			g.LastRoundEffects.Winner = i
-			g.LastRoundEffects.HasWinner = true
-			return
-		}
+	winner, reason := pickWinner(g, contract)
+	if reason != WinNone {
+		g.LastRoundEffects.Winner = winner
+		g.LastRoundEffects.HasWinner = true
+		g.LastRoundEffects.WinReason = reason
+	}
+	recordRound(g, outcome)
+	if reason != WinNone {
+		g.Round.Phase = PhaseGameOver
+		return
 	}
 
 	g.Round.Dealer = (g.Round.Dealer + 1) % g.Rules.Players
@@ -29,9 +29,33 @@ func TestTrickWinnerByRank(t *testing.T) {
 	}
 }
 
+func TestTrickWinnerTwoCards(t *testing.T) {
+	order := []int{1, 0}
+	cards := []Card{
+		{Suit: SuitDiamonds, Rank: Rank10},
+		{Suit: SuitClubs, Rank: RankA},
+	}
+	if winner := trickWinner(order, cards, nil); winner != 1 {
+		t.Fatalf("expected leader to win off-suit trick, got %d", winner)
+	}
+}
+
+func TestTrickWinnerLowRanks(t *testing.T) {
+	order := []int{0, 1, 2, 3}
+	cards := []Card{
+		{Suit: SuitClubs, Rank: Rank7},
+		{Suit: SuitClubs, Rank: Rank9},
+		{Suit: SuitClubs, Rank: Rank8},
+		{Suit: SuitHearts, Rank: RankA},
+	}
+	if winner := trickWinner(order, cards, nil); winner != 1 {
+		t.Fatalf("expected 9 to beat 7 and 8, got %d", winner)
+	}
+}
+
 func TestScoreRoundContractSuccess(t *testing.T) {
 	r := ClassicPreset()
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	g.Round.BidWinner = 0
 	g.Round.BidValue = 20
 	g.Round.Bids = map[int]int{0: 20}
@@ -54,7 +78,7 @@ func TestScoreRoundContractSuccess(t *testing.T) {
 
 func TestScoreRoundContractFail(t *testing.T) {
 	r := ClassicPreset()
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	g.Round.BidWinner = 0
 	g.Round.BidValue = 100
 	g.Round.Bids = map[int]int{0: 100}
@@ -77,7 +101,7 @@ func TestScoreRoundContractFail(t *testing.T) {
 
 func TestGameEndsAtWinScoreNotOnBarrel(t *testing.T) {
 	r := ClassicPreset()
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	g.Players[0].GameScore = r.WinScore
 	g.Round.BidWinner = 0
 	scoreRound(&g)
@@ -88,7 +112,7 @@ func TestGameEndsAtWinScoreNotOnBarrel(t *testing.T) {
 
 func TestGameDoesNotEndOnBarrel(t *testing.T) {
 	r := ClassicPreset()
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	g.Players[0].GameScore = r.WinScore
 	g.Players[0].OnBarrel = true
 	g.Round.BidWinner = 0
@@ -100,7 +124,7 @@ func TestGameDoesNotEndOnBarrel(t *testing.T) {
 
 func TestBoltIncrement(t *testing.T) {
 	r := ClassicPreset()
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	g.Round.BidWinner = 0
 	scoreRound(&g)
 	if g.Players[1].Bolts != 1 || g.Players[2].Bolts != 1 {
@@ -110,10 +134,415 @@ func TestBoltIncrement(t *testing.T) {
 
 func TestBarrelEntry(t *testing.T) {
 	r := ClassicPreset()
-	g := NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	g.Players[1].GameScore = r.BarrelThreshold
 	scoreRound(&g)
 	if !g.Players[1].OnBarrel {
 		t.Fatalf("expected player to be on barrel at threshold")
 	}
 }
+
+func TestBarrelNewcomerPushesOwnerOff(t *testing.T) {
+	r := ClassicPreset()
+	g := newTestGame(t, r, 1)
+	g.Round.BidWinner = -1
+	g.Players[0].GameScore = 900
+	g.Players[0].OnBarrel = true
+	g.Players[0].BarrelAttempts = 1
+	g.Players[2].GameScore = r.BarrelThreshold
+	g.Players[1].Tricks = [][]Card{{{Suit: SuitHearts, Rank: Rank9}}}
+	g.Players[2].Tricks = [][]Card{{{Suit: SuitHearts, Rank: RankJ}}}
+	scoreRound(&g)
+
+	if g.Players[0].OnBarrel || !g.Players[2].OnBarrel {
+		t.Fatalf("expected newcomer to take the barrel")
+	}
+	if g.Players[0].GameScore != 900 {
+		t.Fatalf("expected no push-off penalty, got %d", g.Players[0].GameScore)
+	}
+	e := g.LastRoundEffects
+	if len(e.BarrelPushed) != 1 || e.BarrelPushed[0] != 0 || len(e.BarrelEnter) != 1 || e.BarrelEnter[0] != 2 {
+		t.Fatalf("unexpected barrel effects: %+v", e)
+	}
+}
+
+func TestBarrelPushOffPenalty(t *testing.T) {
+	r := ClassicPreset()
+	r.BarrelPolicy = BarrelPushOffPenalty
+	r.BarrelPushPenalty = 120
+	g := newTestGame(t, r, 1)
+	g.Round.BidWinner = -1
+	g.Players[0].GameScore = 900
+	g.Players[0].OnBarrel = true
+	g.Players[2].GameScore = r.BarrelThreshold
+	scoreRound(&g)
+
+	if g.Players[0].OnBarrel || g.Players[0].GameScore != 780 {
+		t.Fatalf("expected owner pushed off with penalty, got %d", g.Players[0].GameScore)
+	}
+}
+
+func TestBarrelShared(t *testing.T) {
+	r := ClassicPreset()
+	r.BarrelPolicy = BarrelShared
+	g := newTestGame(t, r, 1)
+	g.Round.BidWinner = -1
+	g.Players[0].GameScore = 900
+	g.Players[0].OnBarrel = true
+	g.Players[1].GameScore = 890
+	g.Players[1].OnBarrel = true
+	g.Players[2].GameScore = r.BarrelThreshold
+	scoreRound(&g)
+
+	for i := range g.Players {
+		if !g.Players[i].OnBarrel {
+			t.Fatalf("expected player %d to stay on the shared barrel", i)
+		}
+	}
+	if g.Players[1].BarrelAttempts != 1 || g.Players[2].BarrelAttempts != 1 {
+		t.Fatalf("expected an attempt counted for every player on the barrel, got %d/%d", g.Players[1].BarrelAttempts, g.Players[2].BarrelAttempts)
+	}
+	if len(g.LastRoundEffects.BarrelPushed) != 0 {
+		t.Fatalf("nobody should be pushed off a shared barrel")
+	}
+}
+
+// TestBarrelSingleOwnerKeepsBaselineOrder pins the single-owner outcome
+// from before barrel policies existed: a newcomer knocks the owner off
+// before the owner's attempt is counted, and the newcomer's first round on
+// the barrel already counts as an attempt.
+func TestBarrelSingleOwnerKeepsBaselineOrder(t *testing.T) {
+	r := ClassicPreset()
+	g := newTestGame(t, r, 1)
+	g.Round.BidWinner = -1
+	g.Players[0].GameScore = 900
+	g.Players[0].OnBarrel = true
+	g.Players[0].BarrelAttempts = r.BarrelAttempts - 1
+	g.Players[2].GameScore = r.BarrelThreshold
+	g.Players[1].Tricks = [][]Card{{{Suit: SuitHearts, Rank: Rank9}}}
+	g.Players[2].Tricks = [][]Card{{{Suit: SuitHearts, Rank: RankJ}}}
+	scoreRound(&g)
+
+	if g.Players[0].OnBarrel || g.Players[0].GameScore != 900 || g.Players[0].BarrelAttempts != 0 {
+		t.Fatalf("expected owner knocked off without a penalty, got %+v", g.Players[0])
+	}
+	if !g.Players[2].OnBarrel || g.Players[2].BarrelAttempts != 1 {
+		t.Fatalf("expected newcomer on the barrel after one attempt, got %+v", g.Players[2])
+	}
+	e := g.LastRoundEffects
+	if len(e.BarrelPenalty) != 0 || len(e.BarrelExit) != 1 || e.BarrelExit[0] != 0 {
+		t.Fatalf("unexpected barrel effects: %+v", e)
+	}
+}
+
+func TestBarrelNewcomerMakingTargetReportsBothMoves(t *testing.T) {
+	r := ClassicPreset()
+	g := newTestGame(t, r, 1)
+	g.Round.BidWinner = -1
+	g.Players[2].GameScore = 760
+	g.Players[2].Tricks = [][]Card{{{Suit: SuitHearts, Rank: RankA}}}
+	g.Players[2].MarriagePts = 120
+	scoreRound(&g)
+
+	if g.Players[2].GameScore < r.BarrelThreshold || g.Players[2].OnBarrel {
+		t.Fatalf("expected player to reach the barrel and leave it at once, got %+v", g.Players[2])
+	}
+	e := g.LastRoundEffects
+	if len(e.BarrelEnter) != 1 || e.BarrelEnter[0] != 2 || len(e.BarrelExit) != 1 || e.BarrelExit[0] != 2 {
+		t.Fatalf("expected both barrel moves reported, got %+v", e)
+	}
+}
+
+func TestDefenderScoreCap(t *testing.T) {
+	r := ClassicPreset()
+	r.DefenderScoreCap = 800
+	g := newTestGame(t, r, 1)
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 80
+	g.Players[1].GameScore = 790
+	g.Players[1].Tricks = [][]Card{
+		{{Suit: SuitHearts, Rank: RankA}, {Suit: SuitHearts, Rank: Rank10}, {Suit: SuitHearts, Rank: RankK}},
+	}
+	g.Players[2].GameScore = 700
+	g.Players[2].Tricks = [][]Card{
+		{{Suit: SuitClubs, Rank: RankA}, {Suit: SuitClubs, Rank: Rank10}},
+	}
+	scoreRound(&g)
+
+	if g.Players[1].GameScore != 800 {
+		t.Fatalf("expected defender capped at 800, got %d", g.Players[1].GameScore)
+	}
+	if g.Players[2].GameScore != 721 {
+		t.Fatalf("expected uncapped defender score 721, got %d", g.Players[2].GameScore)
+	}
+	capped := g.LastRoundEffects.Capped
+	if len(capped) != 1 || capped[0] != (ScoreChange{Player: 1, Points: 15}) {
+		t.Fatalf("unexpected capped effects: %+v", capped)
+	}
+}
+
+func TestDefenderScoreCapKeepsDefenderOffBarrel(t *testing.T) {
+	r := ClassicPreset()
+	r.DefenderScoreCap = r.BarrelThreshold
+	g := newTestGame(t, r, 1)
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 80
+	g.Players[1].GameScore = 860
+	g.Players[1].Tricks = [][]Card{
+		{{Suit: SuitHearts, Rank: RankA}, {Suit: SuitHearts, Rank: Rank10}, {Suit: SuitHearts, Rank: RankK}},
+	}
+	scoreRound(&g)
+
+	if g.Players[1].GameScore != r.BarrelThreshold || g.Players[1].OnBarrel {
+		t.Fatalf("expected defender held at the cap off the barrel, got %+v", g.Players[1])
+	}
+	capped := g.LastRoundEffects.Capped
+	if len(capped) != 1 || capped[0] != (ScoreChange{Player: 1, Points: 5}) {
+		t.Fatalf("unexpected capped effects: %+v", capped)
+	}
+}
+
+func TestBarrelNeedsContract(t *testing.T) {
+	for _, needsContract := range []bool{false, true} {
+		r := ClassicPreset()
+		r.BarrelNeedsContract = needsContract
+		r.BarrelPolicy = BarrelShared
+		g := newTestGame(t, r, 1)
+		g.Round.BidWinner = 0
+		g.Round.BidValue = 100
+		g.Players[0].GameScore = 780
+		g.Players[0].Tricks = [][]Card{{{Suit: SuitSpades, Rank: RankA}}}
+		g.Players[0].MarriagePts = 100
+		g.Players[1].GameScore = 860
+		g.Players[1].Tricks = [][]Card{
+			{{Suit: SuitHearts, Rank: RankA}, {Suit: SuitHearts, Rank: Rank10}, {Suit: SuitHearts, Rank: RankK}},
+		}
+		scoreRound(&g)
+
+		if g.Players[1].GameScore != 885 {
+			t.Fatalf("needsContract=%v: expected the defender uncapped at 885, got %d", needsContract, g.Players[1].GameScore)
+		}
+		if g.Players[1].OnBarrel == needsContract {
+			t.Fatalf("needsContract=%v: defender on barrel = %v", needsContract, g.Players[1].OnBarrel)
+		}
+		if !g.Players[0].OnBarrel {
+			t.Fatalf("needsContract=%v: expected the made contract to enter the barrel, got %+v", needsContract, g.Players[0])
+		}
+	}
+}
+
+func TestRoundPoints(t *testing.T) {
+	cases := []struct {
+		mode PointsRounding
+		in   int
+		want int
+	}{
+		{RoundingNone, 67, 67},
+		{RoundingNearest5, 67, 65},
+		{RoundingNearest5, 68, 70},
+		{RoundingNearest5, 70, 70},
+		{RoundingCeil5, 66, 70},
+		{RoundingCeil5, 65, 65},
+	}
+	for _, c := range cases {
+		if got := roundPoints(c.mode, c.in); got != c.want {
+			t.Fatalf("roundPoints(%v, %d) = %d, want %d", c.mode, c.in, got, c.want)
+		}
+	}
+}
+
+func TestScoreRoundRoundsContractPoints(t *testing.T) {
+	r := ClassicPreset()
+	r.PointsRounding = RoundingNearest5
+	g := newTestGame(t, r, 1)
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 80
+	// 78 raw points round up to 80 and make the contract.
+	g.Players[0].Tricks = [][]Card{
+		{{Suit: SuitHearts, Rank: RankA}, {Suit: SuitSpades, Rank: RankA}, {Suit: SuitClubs, Rank: RankA}},
+		{{Suit: SuitDiamonds, Rank: RankA}, {Suit: SuitHearts, Rank: Rank10}, {Suit: SuitSpades, Rank: Rank10}},
+		{{Suit: SuitClubs, Rank: Rank10}, {Suit: SuitDiamonds, Rank: RankJ}, {Suit: SuitHearts, Rank: RankJ}},
+	}
+	g.Players[1].Tricks = [][]Card{
+		{{Suit: SuitDiamonds, Rank: RankK}, {Suit: SuitClubs, Rank: RankQ}, {Suit: SuitClubs, Rank: Rank9}},
+	}
+	scoreRound(&g)
+
+	if g.LastRoundPoints[0] != 80 || g.Players[0].GameScore != 80 {
+		t.Fatalf("expected rounded contract of 80, got %d/%d", g.LastRoundPoints[0], g.Players[0].GameScore)
+	}
+	if g.LastRoundPoints[1] != 5 {
+		t.Fatalf("expected defender points rounded to 5, got %d", g.LastRoundPoints[1])
+	}
+}
+
+func TestScoreRoundUsesRulePointValues(t *testing.T) {
+	r := ClassicPreset()
+	r.CardPoints[Rank9] = 5
+	r.CardPoints[RankA] = 20
+	g := newTestGame(t, r, 1)
+	g.Round.BidWinner = -1
+	g.Players[1].Tricks = [][]Card{
+		{{Suit: SuitHearts, Rank: RankA}, {Suit: SuitSpades, Rank: Rank9}, {Suit: SuitClubs, Rank: Rank9}},
+	}
+	scoreRound(&g)
+	if g.LastRoundPoints[1] != 30 {
+		t.Fatalf("expected house-rule points 30, got %d", g.LastRoundPoints[1])
+	}
+}
+
+func TestTrickOrderSkipsDealer(t *testing.T) {
+	g := newTestGame(t, FourPlayerPreset(), 1)
+	g.Round.Dealer = 1
+	order := buildTrickOrder(g, 0)
+	want := []int{0, 2, 3}
+	if len(order) != len(want) {
+		t.Fatalf("expected order %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, order)
+		}
+	}
+}
+
+func TestScoreRoundDealerCollectsKitty(t *testing.T) {
+	g := newTestGame(t, FourPlayerPreset(), 1)
+	g.Round.Dealer = 3
+	g.Round.DealerPts = 21
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 80
+	scoreRound(&g)
+	if g.Players[3].GameScore != 21 {
+		t.Fatalf("expected dealer to score kitty points, got %d", g.Players[3].GameScore)
+	}
+	if g.Players[3].Bolts != 0 {
+		t.Fatalf("dealer sitting out should not take a bolt")
+	}
+}
+
+func TestScoreRoundRaspasy(t *testing.T) {
+	r := ClassicPreset()
+	r.AllPassPolicy = AllPassRaspasy
+	r.RaspasyKitty = RaspasyKittyFirstTrick
+	g := newTestGame(t, r, 1)
+	g.Round.Raspasy = true
+	g.Round.BidWinner = -1
+	g.Round.KittyOwner = 1
+	g.Round.Kitty = []Card{{Suit: SuitHearts, Rank: RankA}}
+	g.Players[0].Tricks = [][]Card{
+		{{Suit: SuitClubs, Rank: Rank10}, {Suit: SuitClubs, Rank: RankK}, {Suit: SuitClubs, Rank: Rank9}},
+	}
+	g.Players[1].Tricks = [][]Card{
+		{{Suit: SuitSpades, Rank: RankQ}, {Suit: SuitSpades, Rank: RankJ}, {Suit: SuitSpades, Rank: Rank9}},
+	}
+
+	scoreRound(&g)
+	if g.Players[0].GameScore != 14 {
+		t.Fatalf("expected player 0 to score own tricks, got %d", g.Players[0].GameScore)
+	}
+	if g.Players[1].GameScore != 16 {
+		t.Fatalf("expected player 1 to score tricks plus kitty, got %d", g.Players[1].GameScore)
+	}
+	if g.Players[2].Bolts != 1 {
+		t.Fatalf("expected player without tricks to take a bolt")
+	}
+}
+
+func TestScoreRoundFourPlayerRaspasyKittyScoredOnce(t *testing.T) {
+	kitty := []Card{{Suit: SuitHearts, Rank: RankA}, {Suit: SuitHearts, Rank: Rank10}, {Suit: SuitHearts, Rank: Rank9}}
+	for _, tc := range []struct {
+		name      string
+		policy    RaspasyKitty
+		owner     int
+		dealerPts int
+		ownerPts  int
+	}{
+		{"first trick", RaspasyKittyFirstTrick, 1, 0, 21 + 14},
+		{"set aside", RaspasyKittySetAside, -1, 21, 14},
+	} {
+		r := FourPlayerPreset()
+		r.AllPassPolicy = AllPassRaspasy
+		r.RaspasyKitty = tc.policy
+		g := newTestGame(t, r, 1)
+		g.Round.Dealer = 3
+		DealRound(&g)
+		g.Round.Kitty = kitty
+		g.Round.DealerPts = handPoints(r, kitty)
+		g.Round.Raspasy = true
+		g.Round.BidWinner = -1
+		g.Round.KittyOwner = tc.owner
+		for i := range g.Players {
+			g.Players[i].Hand = nil
+		}
+		g.Players[1].Tricks = [][]Card{
+			{{Suit: SuitClubs, Rank: Rank10}, {Suit: SuitClubs, Rank: RankK}, {Suit: SuitClubs, Rank: Rank9}},
+		}
+
+		scoreRound(&g)
+		if g.LastRoundPoints[3] != tc.dealerPts {
+			t.Fatalf("%s: expected dealer to score %d, got %d", tc.name, tc.dealerPts, g.LastRoundPoints[3])
+		}
+		if g.LastRoundPoints[1] != tc.ownerPts {
+			t.Fatalf("%s: expected player 1 to score %d, got %d", tc.name, tc.ownerPts, g.LastRoundPoints[1])
+		}
+	}
+}
+
+func TestScoreRoundAppliesContraMultiplier(t *testing.T) {
+	r := ClassicPreset()
+	g := newTestGame(t, r, 1)
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 20
+	g.Round.Multiplier = 2
+	g.Players[0].Tricks = [][]Card{
+		{{Suit: SuitHearts, Rank: RankA}, {Suit: SuitSpades, Rank: Rank10}},
+	}
+	scoreRound(&g)
+	if g.Players[0].GameScore != 42 {
+		t.Fatalf("expected doubled 21 points, got %d", g.Players[0].GameScore)
+	}
+
+	g = newTestGame(t, r, 1)
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 120
+	g.Round.Multiplier = 4
+	scoreRound(&g)
+	if g.Players[0].GameScore != -480 {
+		t.Fatalf("expected redoubled penalty of 480, got %d", g.Players[0].GameScore)
+	}
+}
+
+func TestTiebreakPolicies(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy TiebreakPolicy
+		scores []int
+		winner int
+		reason WinReason
+	}{
+		{"bidder first", TiebreakBidderFirst, []int{0, 1010, 1020}, 1, WinBidder},
+		{"highest score", TiebreakHighestScore, []int{0, 1010, 1020}, 2, WinHighestScore},
+		{"exact tie goes to bidder", TiebreakHighestScore, []int{1020, 1020, 1020}, 1, WinBidder},
+		{"play on unique top", TiebreakPlayOn, []int{0, 1010, 1020}, 2, WinHighestScore},
+		{"play on shared top", TiebreakPlayOn, []int{1020, 1020, 0}, -1, WinNone},
+		{"sole contender", TiebreakPlayOn, []int{0, 0, 1020}, 2, WinSole},
+	}
+	for _, tc := range cases {
+		r := ClassicPreset()
+		r.TiebreakPolicy = tc.policy
+		g := newTestGame(t, r, 1)
+		g.Round.BidWinner = 1
+		for i, s := range tc.scores {
+			g.Players[i].GameScore = s
+		}
+		scoreRound(&g)
+		if g.LastRoundEffects.Winner != tc.winner || g.LastRoundEffects.WinReason != tc.reason {
+			t.Fatalf("%s: expected winner %d (%v), got %d (%v)", tc.name, tc.winner, tc.reason,
+				g.LastRoundEffects.Winner, g.LastRoundEffects.WinReason)
+		}
+		if (tc.winner >= 0) != (g.Round.Phase == PhaseGameOver) {
+			t.Fatalf("%s: unexpected phase %v", tc.name, g.Round.Phase)
+		}
+	}
+}
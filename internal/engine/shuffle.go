@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/bits"
+)
+
+// Shuffler orders a fresh deck for a deal.
+type Shuffler interface {
+	// Name identifies the algorithm and its version; a shuffler whose
+	// output changes for a given seed must get a new name.
+	Name() string
+	// Shuffle returns a permutation of deck, leaving deck unchanged.
+	Shuffle(deck []Card, seed int64) []Card
+}
+
+// ReplayableShuffler is a Shuffler whose deals anyone can replay from the
+// round seed, so DealRound commits to them and VerifyDeal can check them.
+type ReplayableShuffler interface {
+	Shuffler
+	// RoundSeed derives the shuffle seed for a round from the game seed.
+	// It is part of the shuffler's version: changing it needs a new Name.
+	RoundSeed(seed int64, round int) int64
+}
+
+// StableShuffler runs a Fisher-Yates shuffle on the package's own PCG32
+// generator, so a seed produces the same deal on every Go release. It is
+// the default when GameState.Shuffler is nil.
+//
+// Its round seeds come from RoundSeed, which can be inverted: a revealed
+// round seed gives away the game seed. Use StableShufflerV2 when round
+// seeds are published.
+type StableShuffler struct{}
+
+func (StableShuffler) Name() string { return "pcg32-v1" }
+
+func (StableShuffler) RoundSeed(seed int64, round int) int64 {
+	return RoundSeed(seed, round)
+}
+
+func (StableShuffler) Shuffle(deck []Card, seed int64) []Card {
+	state := uint64(seed)
+	rng := newPCG32(splitmix64(&state), splitmix64(&state))
+	shuffled := append([]Card(nil), deck...)
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j := rng.intn(uint32(i + 1))
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+	return shuffled
+}
+
+// StableShufflerV2 shuffles exactly like StableShuffler but derives round
+// seeds with SHA-256, so revealing a round seed at the end of the round
+// tells nothing about the game seed or later deals.
+type StableShufflerV2 struct{}
+
+func (StableShufflerV2) Name() string { return "pcg32-v2" }
+
+func (StableShufflerV2) Shuffle(deck []Card, seed int64) []Card {
+	return StableShuffler{}.Shuffle(deck, seed)
+}
+
+// RoundSeed hashes the big-endian game seed and round number and keeps the
+// first eight bytes of the digest.
+func (StableShufflerV2) RoundSeed(seed int64, round int) int64 {
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], uint64(seed))
+	binary.BigEndian.PutUint64(buf[8:], uint64(round))
+	sum := sha256.Sum256(buf[:])
+	return int64(binary.BigEndian.Uint64(sum[:8]))
+}
+
+// CryptoShuffler draws from the operating system's secure random source
+// and ignores the seed. Deals cannot be replayed from the game seed, which
+// is what a real-money table wants. Its deals are not committed to.
+type CryptoShuffler struct{}
+
+func (CryptoShuffler) Name() string { return "crypto" }
+
+func (CryptoShuffler) Shuffle(deck []Card, _ int64) []Card {
+	shuffled := append([]Card(nil), deck...)
+	var buf [4]byte
+	for i := len(shuffled) - 1; i > 0; i-- {
+		n := uint32(i + 1)
+		threshold := -n % n
+		for {
+			if _, err := rand.Read(buf[:]); err != nil {
+				panic("crypto shuffler: " + err.Error())
+			}
+			r := binary.LittleEndian.Uint32(buf[:])
+			if r >= threshold {
+				j := r % n
+				shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+				break
+			}
+		}
+	}
+	return shuffled
+}
+
+// Shuffle orders deck with the default StableShuffler.
+func Shuffle(deck []Card, seed int64) []Card {
+	return StableShuffler{}.Shuffle(deck, seed)
+}
+
+const splitmixGamma = 0x9e3779b97f4a7c15
+
+// splitmix64 advances state and returns the next well-mixed value.
+func splitmix64(state *uint64) uint64 {
+	*state += splitmixGamma
+	z := *state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// pcg32 is PCG-XSH-RR with 64-bit state, seeded like the reference
+// pcg32_srandom_r.
+type pcg32 struct {
+	state uint64
+	inc   uint64
+}
+
+func newPCG32(initState, initSeq uint64) *pcg32 {
+	p := &pcg32{inc: initSeq<<1 | 1}
+	p.next()
+	p.state += initState
+	p.next()
+	return p
+}
+
+func (p *pcg32) next() uint32 {
+	old := p.state
+	p.state = old*6364136223846793005 + p.inc
+	xorshifted := uint32(((old >> 18) ^ old) >> 27)
+	rot := int(old >> 59)
+	return bits.RotateLeft32(xorshifted, -rot)
+}
+
+// intn returns an unbiased value in [0, n) by rejection sampling.
+func (p *pcg32) intn(n uint32) uint32 {
+	threshold := -n % n
+	for {
+		if r := p.next(); r >= threshold {
+			return r % n
+		}
+	}
+}
+
+// RandomSeed returns an unpredictable game seed from the operating
+// system's secure random source.
+func RandomSeed() int64 {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		panic("random seed: " + err.Error())
+	}
+	return int64(binary.LittleEndian.Uint64(buf[:]))
+}
@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPCG32MatchesReference(t *testing.T) {
+	// First outputs of the reference pcg32_srandom_r(42, 54).
+	want := []uint32{0xa15c02b7, 0x7b47f409, 0xba1d3330, 0x83d2f293, 0xbfa4784b, 0xcbed606e}
+	p := newPCG32(42, 54)
+	for i, w := range want {
+		if got := p.next(); got != w {
+			t.Fatalf("output %d: got 0x%08x, want 0x%08x", i, got, w)
+		}
+	}
+}
+
+func TestStableShufflerGolden(t *testing.T) {
+	golden := map[int64]string{
+		1:  "[KS 9D 10C QH 9H KD KH 10D AD 10H JC JS QD KC AH 9S AC 10S JD 9C QC JH AS QS]",
+		42: "[9C QS KH QD 9S KC 9H QH JS KD 10D 9D 10H QC JD AD 10S JH JC KS AH AC 10C AS]",
+	}
+	for seed, want := range golden {
+		if got := fmt.Sprint(Shuffle(BuildDeck(ClassicPreset()), seed)); got != want {
+			t.Fatalf("seed %d: got %s, want %s", seed, got, want)
+		}
+	}
+}
+
+func TestDealRoundGolden(t *testing.T) {
+	g := newTestGame(t, ClassicPreset(), 7)
+	DealRound(&g)
+	want := []string{
+		"[QC JC 9C KC 9S KH 10C]",
+		"[AS QH JD AH AC 9H AD]",
+		"[JH JS QD QS KD KS 10H]",
+	}
+	for i, w := range want {
+		if got := fmt.Sprint(g.Players[i].Hand); got != w {
+			t.Fatalf("player %d: got %s, want %s", i, got, w)
+		}
+	}
+	if got := fmt.Sprint(g.Round.Kitty); got != "[10D 10S 9D]" {
+		t.Fatalf("kitty: got %s", got)
+	}
+}
+
+func TestDealRoundGoldenV2(t *testing.T) {
+	g := newTestGame(t, ClassicPreset(), 7)
+	g.Shuffler = StableShufflerV2{}
+	DealRound(&g)
+	want := []string{
+		"[10D QH 9S KD JD 9C AD]",
+		"[QC AS KS JS 10C AC 9H]",
+		"[10S AH JC KH 10H QS QD]",
+	}
+	for i, w := range want {
+		if got := fmt.Sprint(g.Players[i].Hand); got != w {
+			t.Fatalf("player %d: got %s, want %s", i, got, w)
+		}
+	}
+	if got := fmt.Sprint(g.Round.Kitty); got != "[JH 9D KC]" {
+		t.Fatalf("kitty: got %s", got)
+	}
+}
+
+func TestCryptoShufflerPermutesDeck(t *testing.T) {
+	deck := BuildDeck(ClassicPreset())
+	shuffled := CryptoShuffler{}.Shuffle(deck, 0)
+	if len(shuffled) != len(deck) {
+		t.Fatalf("expected %d cards, got %d", len(deck), len(shuffled))
+	}
+	seen := map[Card]bool{}
+	for _, c := range shuffled {
+		if seen[c] {
+			t.Fatalf("duplicate card %v", c)
+		}
+		seen[c] = true
+	}
+	if fmt.Sprint(deck) != fmt.Sprint(BuildDeck(ClassicPreset())) {
+		t.Fatalf("shuffle must not modify the input deck")
+	}
+}
@@ -16,11 +16,18 @@ type ActionRecord struct {
 }
 
 func RunSelfPlayRounds(seed int64, rounds int, maxStepsPerRound int) error {
-	rules := engine.TisyachaPreset()
-	state := engine.NewGame(rules, seed)
+	return RunSelfPlayRoundsWithRules(engine.TisyachaPreset(), seed, rounds, maxStepsPerRound)
+}
+
+// RunSelfPlayRoundsWithRules plays rounds under the given rules, checking
+// invariants after every action.
+func RunSelfPlayRoundsWithRules(rules engine.Rules, seed int64, rounds int, maxStepsPerRound int) error {
+	state, err := engine.NewGame(rules, seed)
+	if err != nil {
+		return err
+	}
 
 	for r := 0; r < rounds; r++ {
-		state.Seed = seed + int64(r)
 		engine.DealRound(&state)
 
 		records := []ActionRecord{}
@@ -63,7 +70,7 @@ func chooseAction(state engine.GameState, player int, legal []engine.Action) eng
 	case engine.PhaseSnos:
 		return discardLowest(state, player)
 	case engine.PhasePlayTricks:
-		return lowestLegalPlay(legal)
+		return lowestLegalPlay(state.Rules, legal)
 	default:
 		sort.Slice(legal, func(i, j int) bool {
 			return actionKey(legal[i]) < actionKey(legal[j])
@@ -75,8 +82,8 @@ func chooseAction(state engine.GameState, player int, legal []engine.Action) eng
 func discardLowest(state engine.GameState, player int) engine.Action {
 	hand := append([]engine.Card(nil), state.Players[player].Hand...)
 	sort.Slice(hand, func(i, j int) bool {
-		pi := engine.CardPoints(hand[i].Rank)
-		pj := engine.CardPoints(hand[j].Rank)
+		pi := state.Rules.CardValue(hand[i].Rank)
+		pj := state.Rules.CardValue(hand[j].Rank)
 		if pi == pj {
 			return engine.RankStrength(hand[i].Rank) < engine.RankStrength(hand[j].Rank)
 		}
@@ -89,14 +96,14 @@ func discardLowest(state engine.GameState, player int) engine.Action {
 	return engine.Action{Type: engine.ActionSnos, Cards: hand[:count]}
 }
 
-func lowestLegalPlay(legal []engine.Action) engine.Action {
+func lowestLegalPlay(rules engine.Rules, legal []engine.Action) engine.Action {
 	best := legal[0]
 	bestScore := 1<<31 - 1
 	for _, a := range legal {
 		if a.Type != engine.ActionPlayCard || a.Card == nil {
 			continue
 		}
-		score := engine.CardPoints(a.Card.Rank)*10 + engine.RankStrength(a.Card.Rank)
+		score := rules.CardValue(a.Card.Rank)*10 + engine.RankStrength(a.Card.Rank)
 		if score < bestScore {
 			bestScore = score
 			best = a
@@ -122,6 +129,14 @@ func actionKey(a engine.Action) string {
 		return fmt.Sprintf("5_play_%d_%d", a.Card.Suit, a.Card.Rank)
 	case engine.ActionRospis:
 		return "6_rospis"
+	case engine.ActionRequestRedeal:
+		return "2_redeal"
+	case engine.ActionRaiseContract:
+		return fmt.Sprintf("4_raise_%04d", a.Bid)
+	case engine.ActionContra:
+		return "7_contra"
+	case engine.ActionRecontra:
+		return "7_recontra"
 	default:
 		return "9_unknown"
 	}
@@ -131,6 +146,11 @@ func checkInvariants(state engine.GameState) error {
 	if state.Round.Phase == engine.PhaseDeal && !state.Round.HandsDealt {
 		return nil
 	}
+	for i, p := range state.Players {
+		if !engine.InRound(state, i) && (len(p.Hand) > 0 || len(p.Tricks) > 0) {
+			return fmt.Errorf("player %d sits out but holds cards", i)
+		}
+	}
 	total, dup := countCards(state)
 	expected := len(engine.BuildDeck(state.Rules))
 	if total != expected {
@@ -139,11 +159,14 @@ func checkInvariants(state engine.GameState) error {
 	if dup {
 		return fmt.Errorf("duplicate card detected")
 	}
-	if len(state.Round.TrickCards) > 3 {
+	if len(state.Round.TrickCards) > state.Rules.ActivePlayers() {
 		return fmt.Errorf("invalid trick size: %d", len(state.Round.TrickCards))
 	}
 	if state.Round.Phase == engine.PhaseBidding || state.Round.Phase == engine.PhaseKittyTake {
-		for _, p := range state.Players {
+		for i, p := range state.Players {
+			if !engine.InRound(state, i) {
+				continue
+			}
 			if len(p.Hand) != state.Rules.DealHandSize {
 				return fmt.Errorf("hand size mismatch in bidding: %d", len(p.Hand))
 			}
@@ -152,7 +175,7 @@ func checkInvariants(state engine.GameState) error {
 			return fmt.Errorf("kitty size mismatch in bidding: %d", len(state.Round.Kitty))
 		}
 	}
-	if state.Round.Phase == engine.PhaseSnos {
+	if state.Round.Phase == engine.PhaseRaise || state.Round.Phase == engine.PhaseSnos {
 		if len(state.Players[state.Round.BidWinner].Hand) != state.Rules.DealHandSize+state.Rules.KittySize {
 			return fmt.Errorf("bidder hand not expanded after kitty")
 		}
@@ -160,7 +183,7 @@ func checkInvariants(state engine.GameState) error {
 			return fmt.Errorf("kitty should be empty after take")
 		}
 		for i, p := range state.Players {
-			if i == state.Round.BidWinner {
+			if i == state.Round.BidWinner || !engine.InRound(state, i) {
 				continue
 			}
 			if len(p.Hand) != state.Rules.DealHandSize {
@@ -168,6 +191,13 @@ func checkInvariants(state engine.GameState) error {
 			}
 		}
 	}
+	if state.Round.Phase == engine.PhaseContra {
+		for i, p := range state.Players {
+			if engine.InRound(state, i) && len(p.Hand) != state.Rules.PlayHandSize {
+				return fmt.Errorf("hand size mismatch in contra: %d", len(p.Hand))
+			}
+		}
+	}
 	if state.Round.Phase == engine.PhasePlayTricks {
 		for _, p := range state.Players {
 			if len(p.Hand) > state.Rules.PlayHandSize {
@@ -175,8 +205,15 @@ func checkInvariants(state engine.GameState) error {
 			}
 		}
 		if totalTricks(state) == 0 && len(state.Round.TrickCards) == 0 {
-			for _, p := range state.Players {
-				if len(p.Hand) != state.Rules.PlayHandSize {
+			expected := state.Rules.PlayHandSize
+			if state.Round.Raspasy {
+				expected = state.Rules.DealHandSize
+			}
+			for i, p := range state.Players {
+				if !engine.InRound(state, i) {
+					continue
+				}
+				if len(p.Hand) != expected {
 					return fmt.Errorf("hand size mismatch at start of play: %d", len(p.Hand))
 				}
 			}
@@ -217,6 +254,9 @@ func countCards(state engine.GameState) (int, bool) {
 	for _, c := range state.Round.Kitty {
 		add(c)
 	}
+	for _, c := range state.Round.DeadHand {
+		add(c)
+	}
 	for _, c := range state.Round.TrickCards {
 		add(c)
 	}
@@ -3,6 +3,7 @@ package engine_test
 import (
 	"testing"
 
+	"thousand/internal/engine"
 	"thousand/internal/engine/sim"
 )
 
@@ -14,6 +15,64 @@ func TestSelfPlayRoundsManySeeds(t *testing.T) {
 	}
 }
 
+func TestSelfPlayFourPlayersManySeeds(t *testing.T) {
+	rules := engine.FourPlayerPreset()
+	for seed := int64(1); seed <= 200; seed++ {
+		if err := sim.RunSelfPlayRoundsWithRules(rules, seed, 10, 500); err != nil {
+			t.Fatalf("self-play failed: %v", err)
+		}
+	}
+}
+
+func TestSelfPlayTwoPlayersManySeeds(t *testing.T) {
+	rules := engine.TwoPlayerPreset()
+	for seed := int64(1); seed <= 200; seed++ {
+		if err := sim.RunSelfPlayRoundsWithRules(rules, seed, 10, 500); err != nil {
+			t.Fatalf("self-play failed: %v", err)
+		}
+	}
+}
+
+func TestSelfPlayForcedBidManySeeds(t *testing.T) {
+	rules := engine.TisyachaPreset()
+	rules.AllPassPolicy = engine.AllPassForcedBid
+	for seed := int64(1); seed <= 200; seed++ {
+		if err := sim.RunSelfPlayRoundsWithRules(rules, seed, 10, 500); err != nil {
+			t.Fatalf("self-play failed: %v", err)
+		}
+	}
+}
+
+func TestSelfPlayRaspasyManySeeds(t *testing.T) {
+	rules := engine.TisyachaPreset()
+	rules.AllPassPolicy = engine.AllPassRaspasy
+	rules.RaspasyKitty = engine.RaspasyKittyFirstTrick
+	for seed := int64(1); seed <= 200; seed++ {
+		if err := sim.RunSelfPlayRoundsWithRules(rules, seed, 10, 500); err != nil {
+			t.Fatalf("self-play failed: %v", err)
+		}
+	}
+}
+
+func TestSelfPlayThirtyTwoCardsManySeeds(t *testing.T) {
+	rules := engine.ThirtyTwoCardPreset()
+	rules.AllPassPolicy = engine.AllPassForcedBid
+	for seed := int64(1); seed <= 200; seed++ {
+		if err := sim.RunSelfPlayRoundsWithRules(rules, seed, 10, 500); err != nil {
+			t.Fatalf("self-play failed: %v", err)
+		}
+	}
+}
+
+func TestSelfPlayContractRaiseManySeeds(t *testing.T) {
+	rules := engine.RaisePreset()
+	for seed := int64(1); seed <= 200; seed++ {
+		if err := sim.RunSelfPlayRoundsWithRules(rules, seed, 10, 500); err != nil {
+			t.Fatalf("self-play failed: %v", err)
+		}
+	}
+}
+
 func FuzzSelfPlayRounds(f *testing.F) {
 	f.Add(int64(1))
 	f.Add(int64(42))
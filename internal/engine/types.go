@@ -20,6 +20,8 @@ const (
 	RankK
 	Rank10
 	RankA
+	Rank7
+	Rank8
 )
 
 func (s Suit) String() string {
@@ -51,6 +53,10 @@ func (r Rank) String() string {
 		return "10"
 	case RankA:
 		return "A"
+	case Rank7:
+		return "7"
+	case Rank8:
+		return "8"
 	default:
 		return "?"
 	}
@@ -76,6 +82,15 @@ const (
 	PhasePlayTricks
 	PhaseScoreRound
 	PhaseGameOver
+	// PhaseContra sits between the snos and the first trick: defenders may
+	// double the contract and the bidder may redouble.
+	PhaseContra
+	// PhaseRospis asks the defenders in turn whether they accept the
+	// bidder's rospis when the rules let them refuse it.
+	PhaseRospis
+	// PhaseRaise gives the bidder one chance to raise the contract after
+	// seeing the kitty and before the snos.
+	PhaseRaise
 )
 
 type Rules struct {
@@ -103,6 +118,152 @@ type Rules struct {
 	BoltEvery              int
 	DumpThreshold          int
 	DumpNegativeThreshold  int
+	DealerSitsOut          bool
+	DealerScoring          DealerScoring
+	DeadHandSize           int
+	SnosToDeadHand         bool
+	AllowContractRaise     bool
+	KittyFaceUp            bool
+	AllPassPolicy          AllPassPolicy
+	ForcedBidSeat          int
+	ForcedBidValue         int
+	RaspasyKitty           RaspasyKitty
+	MarriageOnLeadOnly     bool
+	BidCapWithoutMarriage  int
+	RedealFourNines        bool
+	RedealHandBelow        int
+	RedealKittyBelow       int
+	BarrelPolicy           BarrelPolicy
+	BarrelPushPenalty      int
+	DefenderScoreCap       int
+	BarrelNeedsContract    bool
+	PointsRounding         PointsRounding
+	CardPoints             map[Rank]int
+	MarriagePoints         map[Suit]int
+	AceMarriagePoints      int
+	AllowContra            bool
+	RospisPolicy           RospisPolicy
+	RospisFixedPoints      int
+	RospisRefusable        bool
+	TiebreakPolicy         TiebreakPolicy
+}
+
+// DealerScoring decides what a dealer who sits out the round is credited with.
+type DealerScoring int
+
+const (
+	DealerScoresNothing DealerScoring = iota
+	DealerScoresKitty
+)
+
+// AllPassPolicy decides how a deal is resolved when every player passes.
+type AllPassPolicy int
+
+const (
+	// AllPassRedeal moves the deal to the next dealer.
+	AllPassRedeal AllPassPolicy = iota
+	// AllPassForcedBid makes the seat ForcedBidSeat places after the dealer
+	// play a contract of ForcedBidValue (BidMin when zero).
+	AllPassForcedBid
+	// AllPassRaspasy plays the deal without a contract; every player
+	// scores the points of their own tricks.
+	AllPassRaspasy
+)
+
+// RaspasyKitty decides what happens to the kitty in a raspasy round.
+type RaspasyKitty int
+
+const (
+	RaspasyKittySetAside RaspasyKitty = iota
+	RaspasyKittyFirstTrick
+)
+
+// BarrelPolicy decides what happens when a player climbs onto the barrel
+// while someone else is already on it.
+type BarrelPolicy int
+
+const (
+	// BarrelSingleOwner lets the newcomer knock the current owner off.
+	BarrelSingleOwner BarrelPolicy = iota
+	// BarrelPushOffPenalty knocks the owner off and charges them
+	// BarrelPushPenalty.
+	BarrelPushOffPenalty
+	// BarrelShared lets any number of players sit on the barrel at once.
+	BarrelShared
+)
+
+// PointsRounding decides how a player's round points are rounded before
+// they are scored.
+type PointsRounding int
+
+const (
+	RoundingNone PointsRounding = iota
+	// RoundingNearest5 rounds 67 down to 65 and 68 up to 70.
+	RoundingNearest5
+	// RoundingCeil5 rounds any remainder up to the next multiple of 5.
+	RoundingCeil5
+)
+
+// RospisPolicy decides what each defender is paid when the bidder
+// concedes the contract with a rospis.
+type RospisPolicy int
+
+const (
+	// RospisHalfBid pays half the contract, rounded down.
+	RospisHalfBid RospisPolicy = iota
+	// RospisHalfRounded pays half the contract rounded to the nearest 5.
+	RospisHalfRounded
+	// RospisFixed pays RospisFixedPoints regardless of the contract.
+	RospisFixed
+)
+
+// TiebreakPolicy decides who wins when several players reach WinScore in
+// the same round.
+type TiebreakPolicy int
+
+const (
+	// TiebreakBidderFirst gives the game to the bidder if they are among
+	// the contenders, otherwise to the highest score.
+	TiebreakBidderFirst TiebreakPolicy = iota
+	// TiebreakHighestScore gives the game to the highest score; the bidder
+	// and then the lowest seat break an exact tie.
+	TiebreakHighestScore
+	// TiebreakPlayOn gives the game to a unique highest score and keeps
+	// playing rounds while the top score is shared.
+	TiebreakPlayOn
+)
+
+// WinReason explains how the winner of the game was chosen.
+type WinReason int
+
+const (
+	WinNone WinReason = iota
+	// WinSole means only one player reached WinScore.
+	WinSole
+	// WinBidder means the bidder won a tie among several contenders.
+	WinBidder
+	// WinHighestScore means the winner had the single highest score.
+	WinHighestScore
+	// WinSeatOrder means an exact tie was broken by seat order.
+	WinSeatOrder
+)
+
+// CardValue returns the points a card of the given rank is worth.
+func (r Rules) CardValue(rank Rank) int {
+	return r.CardPoints[rank]
+}
+
+// MarriageValue returns the points for declaring a marriage in the suit.
+func (r Rules) MarriageValue(s Suit) int {
+	return r.MarriagePoints[s]
+}
+
+// ActivePlayers returns how many seats take part in a round.
+func (r Rules) ActivePlayers() int {
+	if r.DealerSitsOut {
+		return r.Players - 1
+	}
+	return r.Players
 }
 
 func ClassicPreset() Rules {
@@ -135,9 +296,85 @@ func TisyachaPreset() Rules {
 		BoltEvery:              3,
 		DumpThreshold:          555,
 		DumpNegativeThreshold:  -555,
+		MarriageOnLeadOnly:     true,
+		CardPoints: map[Rank]int{
+			RankA:  11,
+			Rank10: 10,
+			RankK:  4,
+			RankQ:  3,
+			RankJ:  2,
+			Rank9:  0,
+		},
+		MarriagePoints: map[Suit]int{
+			SuitHearts:   100,
+			SuitDiamonds: 80,
+			SuitClubs:    60,
+			SuitSpades:   40,
+		},
+		AceMarriagePoints: 200,
 	}
 }
 
+// StrictTrumpingPreset obliges a player who cannot follow suit to trump,
+// and to beat a trump already on the table when possible.
+func StrictTrumpingPreset() Rules {
+	r := TisyachaPreset()
+	r.MustTrumpIfVoid = true
+	r.MustOverTrump = true
+	return r
+}
+
+// RaisePreset lets the bidder raise the contract once after taking the
+// kitty.
+func RaisePreset() Rules {
+	r := TisyachaPreset()
+	r.AllowContractRaise = true
+	return r
+}
+
+// KontraPreset lets defenders double the contract after the snos and the
+// bidder redouble it.
+func KontraPreset() Rules {
+	r := TisyachaPreset()
+	r.AllowContra = true
+	return r
+}
+
+// FourPlayerPreset seats four players; the dealer gets no cards and
+// collects the kitty points instead.
+func FourPlayerPreset() Rules {
+	r := TisyachaPreset()
+	r.Players = 4
+	r.DealerSitsOut = true
+	r.DealerScoring = DealerScoresKitty
+	return r
+}
+
+// ThirtyTwoCardPreset adds sevens and eights to the deck for four active
+// players; the kitty grows to four cards so the snos gives each opponent one.
+func ThirtyTwoCardPreset() Rules {
+	r := TisyachaPreset()
+	r.Players = 4
+	r.DeckRanks = []Rank{Rank7, Rank8, Rank9, RankJ, RankQ, RankK, Rank10, RankA}
+	r.KittySize = 4
+	r.SnosCards = 3
+	r.CardPoints[Rank7] = 0
+	r.CardPoints[Rank8] = 0
+	return r
+}
+
+// TwoPlayerPreset deals a third, dead hand that nobody plays; the bidder
+// discards the snos onto it.
+func TwoPlayerPreset() Rules {
+	r := TisyachaPreset()
+	r.Players = 2
+	r.PlayHandSize = 7
+	r.SnosCards = 3
+	r.DeadHandSize = 7
+	r.SnosToDeadHand = true
+	return r
+}
+
 type PlayerState struct {
 	ID             int
 	Hand           []Card
@@ -156,25 +393,53 @@ type RoundState struct {
 	Leader              int
 	Trump               *Suit
 	Kitty               []Card
+	RevealedKitty       []Card
+	DeadHand            []Card
 	HandsDealt          bool
 	Bids                map[int]int
 	Passed              map[int]bool
 	BidTurn             int
 	BidWinner           int
 	BidValue            int
+	ContractRaised      bool
+	Multiplier          int
+	ContraBy            int
+	ContraTurn          int
+	RospisTurn          int
+	RospisRefused       bool
+	ForcedBid           bool
+	Raspasy             bool
+	KittyOwner          int
 	TrickCards          []Card
 	TrickOrder          []int
 	DeclaredMarriages   map[int]map[Suit]bool
 	DeclaredAceMarriage map[int]bool
+	DealerPts           int
+	DealSeed            int64
+	DealCommitment      string
+	DealDeck            []Card
+	Record              RoundSummary
+}
+
+// StakeMultiplier returns the factor applied to the contract after any
+// contra or re-contra.
+func (r RoundState) StakeMultiplier() int {
+	if r.Multiplier < 1 {
+		return 1
+	}
+	return r.Multiplier
 }
 
 type GameState struct {
 	Rules            Rules
 	Seed             int64
+	RoundNumber      int
+	Shuffler         Shuffler
 	Round            RoundState
 	Players          []PlayerState
 	LastRoundPoints  []int
 	LastRoundEffects RoundEffects
+	History          []RoundSummary
 }
 
 type RoundEffects struct {
@@ -183,12 +448,29 @@ type RoundEffects struct {
 	BarrelEnter   []int
 	BarrelExit    []int
 	BarrelPenalty []int
+	BarrelPushed  []int
+	Capped        []ScoreChange
+	Rospis        []ScoreChange
 	Dumped        []int
 	Winner        int
 	HasWinner     bool
+	WinReason     WinReason
+	// Contenders lists everyone who reached WinScore off the barrel when
+	// more than one did.
+	Contenders []int
 }
 
-func NewGame(r Rules, seed int64) GameState {
+// ScoreChange records points added to or withheld from a player's score.
+type ScoreChange struct {
+	Player int
+	Points int
+}
+
+// NewGame validates the rules and sets up a game waiting for its first deal.
+func NewGame(r Rules, seed int64) (GameState, error) {
+	if errs := r.Validate(); len(errs) > 0 {
+		return GameState{}, errs
+	}
 	players := make([]PlayerState, r.Players)
 	for i := 0; i < r.Players; i++ {
 		players[i] = PlayerState{ID: i}
@@ -202,7 +484,70 @@ func NewGame(r Rules, seed int64) GameState {
 			Dealer: 0,
 		},
 		Players: players,
+	}, nil
+}
+
+// Clone returns a deep copy of the game state, so a snapshot taken before
+// an action is not changed by applying it.
+func (g GameState) Clone() GameState {
+	out := g
+	out.Players = make([]PlayerState, len(g.Players))
+	for i, p := range g.Players {
+		p.Hand = append([]Card(nil), p.Hand...)
+		var tricks [][]Card
+		for _, t := range p.Tricks {
+			tricks = append(tricks, append([]Card(nil), t...))
+		}
+		p.Tricks = tricks
+		out.Players[i] = p
+	}
+	out.Round = g.Round.clone()
+	out.LastRoundPoints = append([]int(nil), g.LastRoundPoints...)
+	out.History = append([]RoundSummary(nil), g.History...)
+	return out
+}
+
+func (r RoundState) clone() RoundState {
+	out := r
+	if r.Trump != nil {
+		trump := *r.Trump
+		out.Trump = &trump
+	}
+	out.Kitty = append([]Card(nil), r.Kitty...)
+	out.RevealedKitty = append([]Card(nil), r.RevealedKitty...)
+	out.DeadHand = append([]Card(nil), r.DeadHand...)
+	out.DealDeck = append([]Card(nil), r.DealDeck...)
+	out.TrickCards = append([]Card(nil), r.TrickCards...)
+	out.TrickOrder = append([]int(nil), r.TrickOrder...)
+	if r.Bids != nil {
+		out.Bids = make(map[int]int, len(r.Bids))
+		for k, v := range r.Bids {
+			out.Bids[k] = v
+		}
+	}
+	if r.Passed != nil {
+		out.Passed = make(map[int]bool, len(r.Passed))
+		for k, v := range r.Passed {
+			out.Passed[k] = v
+		}
+	}
+	if r.DeclaredMarriages != nil {
+		out.DeclaredMarriages = make(map[int]map[Suit]bool, len(r.DeclaredMarriages))
+		for k, suits := range r.DeclaredMarriages {
+			out.DeclaredMarriages[k] = make(map[Suit]bool, len(suits))
+			for s, v := range suits {
+				out.DeclaredMarriages[k][s] = v
+			}
+		}
+	}
+	if r.DeclaredAceMarriage != nil {
+		out.DeclaredAceMarriage = make(map[int]bool, len(r.DeclaredAceMarriage))
+		for k, v := range r.DeclaredAceMarriage {
+			out.DeclaredAceMarriage[k] = v
+		}
 	}
+	out.Record = r.Record.clone()
+	return out
 }
 
 func (g *GameState) ResetRound() {
@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RuleError describes a problem with a single Rules field.
+type RuleError struct {
+	Field   string
+	Message string
+}
+
+func (e RuleError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// RuleErrors lists every problem found in a ruleset.
+type RuleErrors []RuleError
+
+func (e RuleErrors) Error() string {
+	parts := make([]string, 0, len(e))
+	for _, err := range e {
+		parts = append(parts, err.Error())
+	}
+	return "invalid rules: " + strings.Join(parts, "; ")
+}
+
+// Bounds on numeric settings the engine loops over or multiplies, so an
+// override cannot overflow the arithmetic or stall the bid enumeration.
+const (
+	maxRuleScore  = 1000000
+	maxBidOptions = 200
+)
+
+// Validate checks that the ruleset can deal and play a round. It returns
+// nil when the rules are usable.
+func (r Rules) Validate() RuleErrors {
+	var errs RuleErrors
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, RuleError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if r.Players < 2 {
+		add("Players", "must be at least 2, got %d", r.Players)
+	}
+	if r.DealerSitsOut && r.Players < 3 {
+		add("DealerSitsOut", "needs at least 3 players, got %d", r.Players)
+	}
+	active := r.ActivePlayers()
+	deck := 4 * len(r.DeckRanks)
+
+	if len(r.DeckRanks) == 0 {
+		add("DeckRanks", "must not be empty")
+	}
+	seen := map[Rank]bool{}
+	for _, rank := range r.DeckRanks {
+		if rank.String() == "?" {
+			add("DeckRanks", "unknown rank %d", int(rank))
+			continue
+		}
+		if seen[rank] {
+			add("DeckRanks", "duplicate rank %s", rank)
+		}
+		seen[rank] = true
+		if _, ok := r.CardPoints[rank]; !ok {
+			add("CardPoints", "missing value for rank %s", rank)
+		}
+	}
+	for _, s := range []Suit{SuitClubs, SuitDiamonds, SuitHearts, SuitSpades} {
+		if _, ok := r.MarriagePoints[s]; !ok {
+			add("MarriagePoints", "missing value for suit %s", s)
+		}
+	}
+
+	if r.Players > deck {
+		add("Players", "must not exceed the %d-card deck, got %d", deck, r.Players)
+	}
+	if r.DealHandSize <= 0 || r.DealHandSize > deck {
+		add("DealHandSize", "must be between 1 and %d, got %d", deck, r.DealHandSize)
+	}
+	if r.PlayHandSize <= 0 || r.PlayHandSize > deck {
+		add("PlayHandSize", "must be between 1 and %d, got %d", deck, r.PlayHandSize)
+	}
+	if r.KittySize < 0 || r.KittySize > deck {
+		add("KittySize", "must be between 0 and %d, got %d", deck, r.KittySize)
+	}
+	if r.DeadHandSize < 0 || r.DeadHandSize > deck {
+		add("DeadHandSize", "must be between 0 and %d, got %d", deck, r.DeadHandSize)
+	}
+	if active > 0 && active <= deck && r.DealHandSize*active+r.KittySize+r.DeadHandSize != deck {
+		add("DealHandSize", "%d hands of %d plus kitty %d and dead hand %d must exhaust the %d-card deck",
+			active, r.DealHandSize, r.KittySize, r.DeadHandSize, deck)
+	}
+
+	if r.SnosCards < 0 || r.SnosCards > deck {
+		add("SnosCards", "must be between 0 and %d, got %d", deck, r.SnosCards)
+	}
+	if r.DealHandSize+r.KittySize-r.SnosCards != r.PlayHandSize {
+		add("SnosCards", "bidder keeps %d cards after the snos, PlayHandSize is %d",
+			r.DealHandSize+r.KittySize-r.SnosCards, r.PlayHandSize)
+	}
+	if r.SnosToDeadHand {
+		if r.DealHandSize != r.PlayHandSize {
+			add("PlayHandSize", "must equal DealHandSize when the snos goes to the dead hand")
+		}
+	} else {
+		// Opponents only reach PlayHandSize by taking one snos card each.
+		if r.SnosCards != active-1 {
+			add("SnosCards", "must give one card to each of the %d opponents, got %d", active-1, r.SnosCards)
+		}
+		if r.DealHandSize+1 != r.PlayHandSize {
+			add("PlayHandSize", "opponents hold %d cards after the snos, got %d", r.DealHandSize+1, r.PlayHandSize)
+		}
+	}
+
+	if r.WinScore <= 0 || r.WinScore > maxRuleScore {
+		add("WinScore", "must be between 1 and %d, got %d", maxRuleScore, r.WinScore)
+	}
+	if r.MaxBid < 0 || r.MaxBid > maxRuleScore {
+		add("MaxBid", "must be between 0 and %d, got %d", maxRuleScore, r.MaxBid)
+	}
+	if r.BidStep <= 0 {
+		add("BidStep", "must be positive, got %d", r.BidStep)
+	}
+	if r.BidMin <= 0 {
+		add("BidMin", "must be positive, got %d", r.BidMin)
+	}
+	if r.BidMin > maxBid(r) {
+		add("BidMin", "must not exceed the maximum bid %d, got %d", maxBid(r), r.BidMin)
+	} else if r.BidStep > 0 && r.BidMin > 0 && maxBid(r) <= maxRuleScore && (maxBid(r)-r.BidMin)/r.BidStep >= maxBidOptions {
+		add("BidStep", "allows more than %d bids between %d and %d, got step %d", maxBidOptions, r.BidMin, maxBid(r), r.BidStep)
+	}
+	if r.BidCapWithoutMarriage != 0 && r.BidCapWithoutMarriage < r.BidMin {
+		add("BidCapWithoutMarriage", "must be 0 or at least BidMin, got %d", r.BidCapWithoutMarriage)
+	} else if r.BidCapWithoutMarriage != 0 && r.BidStep > 0 && (r.BidCapWithoutMarriage-r.BidMin)%r.BidStep != 0 {
+		add("BidCapWithoutMarriage", "must be BidMin %d plus a multiple of BidStep %d, got %d", r.BidMin, r.BidStep, r.BidCapWithoutMarriage)
+	}
+	if r.ForcedBidSeat < 0 || r.ForcedBidSeat > active {
+		add("ForcedBidSeat", "must be between 0 and %d, got %d", active, r.ForcedBidSeat)
+	}
+	if r.AllPassPolicy == AllPassForcedBid && r.ForcedBidValue != 0 {
+		if r.ForcedBidValue < r.BidMin || r.ForcedBidValue > maxBid(r) {
+			add("ForcedBidValue", "must be 0 or between %d and %d, got %d", r.BidMin, maxBid(r), r.ForcedBidValue)
+		}
+	}
+
+	if r.BarrelThreshold <= 0 || r.BarrelThreshold >= r.WinScore {
+		add("BarrelThreshold", "must be between 0 and WinScore %d, got %d", r.WinScore, r.BarrelThreshold)
+	}
+	if r.BarrelTarget <= 0 {
+		add("BarrelTarget", "must be positive, got %d", r.BarrelTarget)
+	}
+	if r.BarrelAttempts <= 0 {
+		add("BarrelAttempts", "must be positive, got %d", r.BarrelAttempts)
+	}
+	if r.BarrelPushPenalty < 0 {
+		add("BarrelPushPenalty", "must not be negative, got %d", r.BarrelPushPenalty)
+	}
+	if r.DefenderScoreCap < 0 {
+		add("DefenderScoreCap", "must not be negative, got %d", r.DefenderScoreCap)
+	}
+	if r.RospisPolicy == RospisFixed && r.RospisFixedPoints <= 0 {
+		add("RospisFixedPoints", "must be positive with a fixed rospis, got %d", r.RospisFixedPoints)
+	}
+	if r.BoltEvery <= 0 {
+		add("BoltEvery", "must be positive, got %d", r.BoltEvery)
+	}
+	return errs
+}
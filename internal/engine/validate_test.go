@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestValidateReportsEveryField(t *testing.T) {
+	r := ClassicPreset()
+	r.BidStep = 0
+	r.KittySize = 4
+	r.BarrelThreshold = r.WinScore
+	errs := r.Validate()
+
+	fields := map[string]bool{}
+	for _, e := range errs {
+		fields[e.Field] = true
+	}
+	for _, want := range []string{"BidStep", "DealHandSize", "SnosCards", "BarrelThreshold"} {
+		if !fields[want] {
+			t.Fatalf("expected an error for %s, got %v", want, errs)
+		}
+	}
+}
+
+func TestValidateRejectsMissingCardPoints(t *testing.T) {
+	r := ClassicPreset()
+	delete(r.CardPoints, RankA)
+	errs := r.Validate()
+	if len(errs) != 1 || errs[0].Field != "CardPoints" {
+		t.Fatalf("expected a single CardPoints error, got %v", errs)
+	}
+}
+
+func TestValidateRejectsOffGridBidCap(t *testing.T) {
+	r := ClassicPreset()
+	r.BidCapWithoutMarriage = 125
+	errs := r.Validate()
+	if len(errs) != 1 || errs[0].Field != "BidCapWithoutMarriage" {
+		t.Fatalf("expected a single BidCapWithoutMarriage error, got %v", errs)
+	}
+	r.BidCapWithoutMarriage = 120
+	if errs := r.Validate(); len(errs) > 0 {
+		t.Fatalf("on-grid cap rejected: %v", errs)
+	}
+}
+
+func TestValidateRejectsMissingSnos(t *testing.T) {
+	r := ClassicPreset()
+	r.SnosCards = 0
+	r.PlayHandSize = r.DealHandSize + r.KittySize
+	fields := map[string]bool{}
+	for _, e := range r.Validate() {
+		fields[e.Field] = true
+	}
+	if !fields["SnosCards"] || !fields["PlayHandSize"] {
+		t.Fatalf("expected SnosCards and PlayHandSize errors, got %v", r.Validate())
+	}
+}
+
+func TestValidateBoundsNumericSettings(t *testing.T) {
+	cases := []struct {
+		field string
+		edit  func(r *Rules)
+	}{
+		{"MaxBid", func(r *Rules) { r.MaxBid = math.MaxInt64; r.BidStep = 1 }},
+		{"WinScore", func(r *Rules) { r.MaxBid = 0; r.WinScore = math.MaxInt64 }},
+		{"BidStep", func(r *Rules) { r.MaxBid = 0; r.WinScore = maxRuleScore; r.BidStep = 1 }},
+		{"ForcedBidSeat", func(r *Rules) { r.ForcedBidSeat = math.MaxInt64 }},
+		{"ForcedBidSeat", func(r *Rules) { r.ForcedBidSeat = -1 }},
+		{"Players", func(r *Rules) { r.Players = math.MaxInt64 }},
+		{"DealHandSize", func(r *Rules) { r.DealHandSize = math.MaxInt64 }},
+		{"KittySize", func(r *Rules) { r.KittySize = math.MaxInt64 }},
+	}
+	for _, c := range cases {
+		r := ClassicPreset()
+		c.edit(&r)
+		found := false
+		for _, e := range r.Validate() {
+			found = found || e.Field == c.field
+		}
+		if !found {
+			t.Fatalf("expected an error for %s, got %v", c.field, r.Validate())
+		}
+	}
+}
+
+// TestNewGameRejectsUndealableRules checks that any ruleset NewGame accepts
+// can be dealt, including sizes whose product wraps around.
+func TestNewGameRejectsUndealableRules(t *testing.T) {
+	sizes := []int{-1, 0, 1, 3, 7, 8, 10, 24, 25, math.MaxInt64/2 + 7}
+	for _, players := range []int{2, 3, 4, 5} {
+		for _, sitsOut := range []bool{false, true} {
+			for _, hand := range sizes {
+				for _, kitty := range sizes {
+					for _, dead := range sizes {
+						r := ClassicPreset()
+						r.Players = players
+						r.DealerSitsOut = sitsOut
+						r.DealHandSize = hand
+						r.PlayHandSize = hand
+						r.KittySize = kitty
+						r.DeadHandSize = dead
+						r.SnosCards = kitty
+						r.SnosToDeadHand = true
+						g, err := NewGame(r, 1)
+						if err != nil {
+							continue
+						}
+						func() {
+							defer func() {
+								if p := recover(); p != nil {
+									t.Fatalf("NewGame accepted %d players (sits out %v), hand %d, kitty %d, dead %d but dealing panicked: %v",
+										players, sitsOut, hand, kitty, dead, p)
+								}
+							}()
+							DealRound(&g)
+						}()
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestNewGameRejectsInvalidRules(t *testing.T) {
+	r := ClassicPreset()
+	r.Players = 1
+	if _, err := NewGame(r, 1); err == nil {
+		t.Fatalf("expected NewGame to reject a one-player game")
+	}
+}
+
+func TestRegisteredPresetsValidate(t *testing.T) {
+	for _, p := range Presets() {
+		if errs := p.Rules().Validate(); len(errs) > 0 {
+			t.Fatalf("%s preset invalid: %v", p.Name, errs)
+		}
+	}
+}
+
+func TestLookupPreset(t *testing.T) {
+	if _, ok := LookupPreset(""); !ok {
+		t.Fatalf("empty name should select the default preset")
+	}
+	r, ok := LookupPreset("strict_trumping")
+	if !ok || !r.MustTrumpIfVoid || !r.MustOverTrump {
+		t.Fatalf("strict_trumping preset not registered correctly: %+v", r)
+	}
+	if _, ok := LookupPreset("nope"); ok {
+		t.Fatalf("unknown preset should not resolve")
+	}
+}
@@ -63,6 +63,16 @@ func (a *ActionDTO) ToEngine() (engine.Action, error) {
 		return engine.Action{Type: engine.ActionPlayCard, Card: &card, MarriageSuit: marriage}, nil
 	case "rospis":
 		return engine.Action{Type: engine.ActionRospis}, nil
+	case "raise_contract":
+		return engine.Action{Type: engine.ActionRaiseContract, Bid: a.Bid}, nil
+	case "request_redeal":
+		return engine.Action{Type: engine.ActionRequestRedeal}, nil
+	case "contra":
+		return engine.Action{Type: engine.ActionContra}, nil
+	case "recontra":
+		return engine.Action{Type: engine.ActionRecontra}, nil
+	case "refuse_rospis":
+		return engine.Action{Type: engine.ActionRefuseRospis}, nil
 	default:
 		return engine.Action{}, errors.New("unknown action type")
 	}
@@ -94,6 +104,16 @@ func ActionFromEngine(a engine.Action) ActionDTO {
 		return out
 	case engine.ActionRospis:
 		return ActionDTO{Type: "rospis"}
+	case engine.ActionRaiseContract:
+		return ActionDTO{Type: "raise_contract", Bid: a.Bid}
+	case engine.ActionRequestRedeal:
+		return ActionDTO{Type: "request_redeal"}
+	case engine.ActionContra:
+		return ActionDTO{Type: "contra"}
+	case engine.ActionRecontra:
+		return ActionDTO{Type: "recontra"}
+	case engine.ActionRefuseRospis:
+		return ActionDTO{Type: "refuse_rospis"}
 	default:
 		return ActionDTO{Type: "unknown"}
 	}
@@ -144,6 +164,10 @@ func parseRank(r string) (engine.Rank, error) {
 		return engine.Rank10, nil
 	case "A":
 		return engine.RankA, nil
+	case "7":
+		return engine.Rank7, nil
+	case "8":
+		return engine.Rank8, nil
 	default:
 		return engine.Rank9, errors.New("invalid rank")
 	}
@@ -178,6 +202,10 @@ func rankToString(r engine.Rank) string {
 		return "10"
 	case engine.RankA:
 		return "A"
+	case engine.Rank7:
+		return "7"
+	case engine.Rank8:
+		return "8"
 	default:
 		return "?"
 	}
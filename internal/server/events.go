@@ -1,6 +1,12 @@
 package server
 
-import "thousand/internal/engine"
+import (
+	"log"
+	"sort"
+	"strconv"
+
+	"thousand/internal/engine"
+)
 
 type EventPayload struct {
 	Player    int            `json:"player"`
@@ -11,6 +17,19 @@ type EventPayload struct {
 	Points    []int          `json:"points,omitempty"`
 	Value     int            `json:"value,omitempty"`
 	Transfers []SnosTransfer `json:"transfers,omitempty"`
+	Reason    string         `json:"reason,omitempty"`
+	Standings []Standing     `json:"standings,omitempty"`
+	// Deal commitment and reveal; the seed is a decimal string because
+	// JSON numbers cannot carry every int64 exactly.
+	Commitment string `json:"commitment,omitempty"`
+	Seed       string `json:"seed,omitempty"`
+	Shuffler   string `json:"shuffler,omitempty"`
+}
+
+// Standing is one line of the final table, highest score first.
+type Standing struct {
+	Player int `json:"player"`
+	Score  int `json:"score"`
 }
 
 type SnosTransfer struct {
@@ -24,12 +43,28 @@ func buildEvents(prev engine.GameState, next engine.GameState, player int, actio
 	case engine.ActionBid:
 		events = append(events, Event{Type: "bid_made", Data: EventPayload{Player: player, Bid: action.Bid}})
 	case engine.ActionPass:
+		if prev.Round.Phase == engine.PhaseContra {
+			events = append(events, Event{Type: "contra_passed", Data: EventPayload{Player: player}})
+			break
+		}
+		if prev.Round.Phase == engine.PhaseRospis {
+			events = append(events, Event{Type: "rospis_accepted", Data: EventPayload{Player: player}})
+			break
+		}
+		if prev.Round.Phase == engine.PhaseRaise {
+			events = append(events, Event{Type: "contract_kept", Data: EventPayload{Player: player, Bid: prev.Round.BidValue}})
+			break
+		}
 		events = append(events, Event{Type: "bid_passed", Data: EventPayload{Player: player}})
 	case engine.ActionTakeKitty:
 		events = append(events, Event{Type: "kitty_taken", Data: EventPayload{Player: player}})
 	case engine.ActionSnos:
+		if prev.Rules.SnosToDeadHand {
+			events = append(events, Event{Type: "snos_made", Data: EventPayload{Player: player, Value: len(action.Cards)}})
+			break
+		}
 		transfers := make([]SnosTransfer, 0, len(action.Cards))
-		opponents := orderedOpponents(player, prev.Rules.Players)
+		opponents := engine.Opponents(prev, player)
 		for i, c := range action.Cards {
 			if i >= len(opponents) {
 				break
@@ -42,10 +77,45 @@ func buildEvents(prev engine.GameState, next engine.GameState, player int, actio
 			events = append(events, Event{Type: "card_played", Data: EventPayload{Player: player, Cards: []CardDTO{cardToDTO(*action.Card)}}})
 		}
 		if action.MarriageSuit != nil {
-			events = append(events, Event{Type: "marriage_declared", Data: EventPayload{Player: player, Suit: suitToString(*action.MarriageSuit), Value: marriageValueForSuit(*action.MarriageSuit)}})
+			events = append(events, Event{Type: "marriage_declared", Data: EventPayload{Player: player, Suit: suitToString(*action.MarriageSuit), Value: prev.Rules.MarriageValue(*action.MarriageSuit)}})
 		}
 	case engine.ActionRospis:
 		events = append(events, Event{Type: "rospis_declared", Data: EventPayload{Player: player}})
+	case engine.ActionRaiseContract:
+		events = append(events, Event{Type: "contract_raised", Data: EventPayload{Player: player, Bid: action.Bid}})
+	case engine.ActionContra:
+		events = append(events, Event{Type: "contra_declared", Data: EventPayload{Player: player, Value: next.Round.Multiplier}})
+	case engine.ActionRecontra:
+		events = append(events, Event{Type: "recontra_declared", Data: EventPayload{Player: player, Value: next.Round.Multiplier}})
+	case engine.ActionRefuseRospis:
+		events = append(events, Event{Type: "rospis_refused", Data: EventPayload{Player: player}})
+	case engine.ActionRequestRedeal:
+		reason, trigger := engine.RedealTrigger(prev, player)
+		cards := make([]CardDTO, 0, len(trigger))
+		for _, c := range trigger {
+			cards = append(cards, cardToDTO(c))
+		}
+		events = append(events, Event{Type: "redeal_requested", Data: EventPayload{Player: player, Cards: cards, Reason: redealReasonToString(reason)}})
+	}
+
+	// Everyone passed
+	if prev.Round.Phase == engine.PhaseBidding && action.Type == engine.ActionPass {
+		if next.Round.Phase == engine.PhaseDeal {
+			events = append(events, Event{Type: "all_passed", Data: EventPayload{Player: next.Round.Dealer, Reason: "redeal"}})
+		} else if next.Round.ForcedBid {
+			events = append(events, Event{Type: "all_passed", Data: EventPayload{Player: next.Round.BidWinner, Bid: next.Round.BidValue, Reason: "forced_bid"}})
+		} else if next.Round.Raspasy {
+			events = append(events, Event{Type: "all_passed", Data: EventPayload{Player: next.Round.Leader, Reason: "raspasy"}})
+		}
+	}
+
+	// Kitty turned face up once the bidding is won
+	if len(prev.Round.RevealedKitty) == 0 && len(next.Round.RevealedKitty) > 0 {
+		cards := make([]CardDTO, 0, len(next.Round.RevealedKitty))
+		for _, c := range next.Round.RevealedKitty {
+			cards = append(cards, cardToDTO(c))
+		}
+		events = append(events, Event{Type: "kitty_revealed", Data: EventPayload{Player: next.Round.BidWinner, Cards: cards}})
 	}
 
 	// Trick won
@@ -54,65 +124,184 @@ func buildEvents(prev engine.GameState, next engine.GameState, player int, actio
 			points := 0
 			last := next.Players[i].Tricks[len(next.Players[i].Tricks)-1]
 			for _, c := range last {
-				points += engine.CardPoints(c.Rank)
+				points += next.Rules.CardValue(c.Rank)
 			}
 			events = append(events, Event{Type: "trick_won", Data: EventPayload{Player: i, Value: points}})
 		}
 	}
+	// Raspasy kitty goes to the winner of the first trick
+	if next.Round.Raspasy && prev.Round.KittyOwner < 0 && next.Round.KittyOwner >= 0 {
+		cards := make([]CardDTO, 0, len(next.Round.Kitty))
+		points := 0
+		for _, c := range next.Round.Kitty {
+			cards = append(cards, cardToDTO(c))
+			points += next.Rules.CardValue(c.Rank)
+		}
+		events = append(events, Event{Type: "kitty_awarded", Data: EventPayload{Player: next.Round.KittyOwner, Cards: cards, Value: points}})
+	}
 	// Ace marriage auto-declared
 	for i := range next.Players {
 		if !prev.Round.DeclaredAceMarriage[i] && next.Round.DeclaredAceMarriage[i] {
-			events = append(events, Event{Type: "ace_marriage_declared", Data: EventPayload{Player: i, Value: 200}})
+			events = append(events, Event{Type: "ace_marriage_declared", Data: EventPayload{Player: i, Value: next.Rules.AceMarriagePoints}})
+		}
+	}
+	// Rospis settled; the payments are reported per player
+	if len(next.LastRoundEffects.Rospis) > 0 &&
+		(next.Round.Phase == engine.PhaseDeal || next.Round.Phase == engine.PhaseGameOver) &&
+		(prev.Round.Phase == engine.PhasePlayTricks || prev.Round.Phase == engine.PhaseRospis) {
+		points := make([]int, len(next.Players))
+		for _, c := range next.LastRoundEffects.Rospis {
+			points[c.Player] = c.Points
 		}
+		events = append(events, Event{Type: "rospis_settled", Data: EventPayload{Player: prev.Round.BidWinner, Points: points}})
+		events = append(events, roundEffectEvents(next)...)
 	}
-	// Round scored
-	if prev.Round.Phase != next.Round.Phase && next.Round.Phase == engine.PhaseDeal {
+	// The deal is over; reveal its seed so clients can replay the shuffle
+	if prev.Round.DealCommitment != "" && prev.Round.Phase != engine.PhaseGameOver &&
+		(next.Round.Phase == engine.PhaseGameOver || (next.Round.Phase == engine.PhaseDeal && !next.Round.HandsDealt)) {
+		events = append(events, dealRevealedEvent(prev))
+	}
+	// Round scored; redeals and rospis also return to the deal but score nothing
+	if prev.Round.Phase == engine.PhasePlayTricks && action.Type == engine.ActionPlayCard && next.Round.Phase != engine.PhasePlayTricks {
 		points := append([]int(nil), next.LastRoundPoints...)
 		events = append(events, Event{Type: "round_scored", Data: EventPayload{Points: points}})
-		for _, p := range next.LastRoundEffects.Bolts {
-			events = append(events, Event{Type: "bolt_awarded", Data: EventPayload{Player: p}})
-		}
-		for _, p := range next.LastRoundEffects.BoltPenalties {
-			events = append(events, Event{Type: "bolt_penalty", Data: EventPayload{Player: p, Value: next.Rules.BoltPenalty}})
-		}
-		for _, p := range next.LastRoundEffects.BarrelEnter {
-			events = append(events, Event{Type: "barrel_enter", Data: EventPayload{Player: p}})
-		}
-		for _, p := range next.LastRoundEffects.BarrelExit {
-			events = append(events, Event{Type: "barrel_exit", Data: EventPayload{Player: p}})
-		}
-		for _, p := range next.LastRoundEffects.BarrelPenalty {
-			events = append(events, Event{Type: "barrel_penalty", Data: EventPayload{Player: p, Value: next.Rules.BoltPenalty}})
-		}
-		for _, p := range next.LastRoundEffects.Dumped {
-			events = append(events, Event{Type: "dump_reset", Data: EventPayload{Player: p}})
-		}
-		if next.LastRoundEffects.HasWinner {
-			events = append(events, Event{Type: "game_ended", Data: EventPayload{Player: next.LastRoundEffects.Winner}})
+		events = append(events, roundEffectEvents(next)...)
+	}
+	return events
+}
+
+// roundEffectEvents reports what the end of a scored round did to each
+// player, from bolts to the end of the game.
+func roundEffectEvents(next engine.GameState) []Event {
+	var events []Event
+	for _, p := range next.LastRoundEffects.Bolts {
+		events = append(events, Event{Type: "bolt_awarded", Data: EventPayload{Player: p}})
+	}
+	for _, p := range next.LastRoundEffects.BoltPenalties {
+		events = append(events, Event{Type: "bolt_penalty", Data: EventPayload{Player: p, Value: next.Rules.BoltPenalty}})
+	}
+	for _, p := range next.LastRoundEffects.BarrelEnter {
+		events = append(events, Event{Type: "barrel_enter", Data: EventPayload{Player: p}})
+	}
+	for _, p := range next.LastRoundEffects.BarrelExit {
+		events = append(events, Event{Type: "barrel_exit", Data: EventPayload{Player: p}})
+	}
+	for _, p := range next.LastRoundEffects.BarrelPenalty {
+		events = append(events, Event{Type: "barrel_penalty", Data: EventPayload{Player: p, Value: next.Rules.BoltPenalty}})
+	}
+	for _, p := range next.LastRoundEffects.BarrelPushed {
+		penalty := 0
+		if next.Rules.BarrelPolicy == engine.BarrelPushOffPenalty {
+			penalty = next.Rules.BarrelPushPenalty
 		}
+		events = append(events, Event{Type: "barrel_pushed", Data: EventPayload{Player: p, Value: penalty}})
+	}
+	for _, c := range next.LastRoundEffects.Capped {
+		events = append(events, Event{Type: "defender_capped", Data: EventPayload{Player: c.Player, Value: c.Points}})
+	}
+	for _, p := range next.LastRoundEffects.Dumped {
+		events = append(events, Event{Type: "dump_reset", Data: EventPayload{Player: p}})
+	}
+	if next.LastRoundEffects.HasWinner {
+		events = append(events, Event{Type: "game_ended", Data: EventPayload{
+			Player:    next.LastRoundEffects.Winner,
+			Reason:    winReasonToString(next.LastRoundEffects.WinReason),
+			Standings: buildStandings(next),
+		}})
+	} else if len(next.LastRoundEffects.Contenders) > 1 {
+		events = append(events, Event{Type: "game_tied", Data: EventPayload{Player: -1, Standings: buildStandings(next)}})
 	}
 	return events
 }
 
-func marriageValueForSuit(s engine.Suit) int {
-	switch s {
-	case engine.SuitHearts:
-		return 100
-	case engine.SuitDiamonds:
-		return 80
-	case engine.SuitClubs:
-		return 60
-	case engine.SuitSpades:
-		return 40
-	default:
-		return 0
+func shufflerName(g engine.GameState) string {
+	if g.Shuffler == nil {
+		return engine.StableShuffler{}.Name()
 	}
+	return g.Shuffler.Name()
 }
 
-func orderedOpponents(player int, players int) []int {
-	out := []int{}
-	for i := 1; i < players; i++ {
-		out = append(out, (player+i)%players)
+// dealCommittedEvents announces the commitment for the current deal. Deals
+// from a shuffler that cannot be replayed are not committed to and get no
+// event.
+func dealCommittedEvents(g engine.GameState) []Event {
+	if g.Round.DealCommitment == "" {
+		return nil
 	}
+	return []Event{{Type: "deal_committed", Data: EventPayload{
+		Player:     g.Round.Dealer,
+		Value:      g.RoundNumber,
+		Commitment: g.Round.DealCommitment,
+		Shuffler:   shufflerName(g),
+	}}}
+}
+
+// dealRevealedEvent publishes the seed and deck order of the deal that just
+// ended, so a client can recompute the commitment without replaying the
+// shuffle. The server replays it first, so a broken commitment shows up in
+// the logs.
+func dealRevealedEvent(g engine.GameState) Event {
+	if _, err := engine.VerifyDeal(g.Rules, g.Shuffler, g.Round.DealSeed, g.Round.DealCommitment); err != nil {
+		log.Printf("deal verification failed: round=%d err=%v", g.RoundNumber, err)
+	}
+	return Event{Type: "deal_revealed", Data: EventPayload{
+		Player:     g.Round.Dealer,
+		Value:      g.RoundNumber,
+		Commitment: g.Round.DealCommitment,
+		Seed:       strconv.FormatInt(g.Round.DealSeed, 10),
+		Cards:      cardsToDTO(g.Round.DealDeck),
+		Shuffler:   shufflerName(g),
+	}}
+}
+
+// buildStandings orders players by score; the winner always comes first
+// and equal scores keep seat order.
+func buildStandings(g engine.GameState) []Standing {
+	out := make([]Standing, 0, len(g.Players))
+	for i, p := range g.Players {
+		out = append(out, Standing{Player: i, Score: p.GameScore})
+	}
+	winner := -1
+	if g.LastRoundEffects.HasWinner {
+		winner = g.LastRoundEffects.Winner
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if (a.Player == winner) != (b.Player == winner) {
+			return a.Player == winner
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Player < b.Player
+	})
 	return out
 }
+
+func winReasonToString(r engine.WinReason) string {
+	switch r {
+	case engine.WinSole:
+		return "sole"
+	case engine.WinBidder:
+		return "bidder"
+	case engine.WinHighestScore:
+		return "highest_score"
+	case engine.WinSeatOrder:
+		return "seat_order"
+	default:
+		return ""
+	}
+}
+
+func redealReasonToString(r engine.RedealReason) string {
+	switch r {
+	case engine.RedealFourNines:
+		return "four_nines"
+	case engine.RedealWeakHand:
+		return "weak_hand"
+	case engine.RedealWeakKitty:
+		return "weak_kitty"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,436 @@
+package server
+
+import (
+	"strconv"
+	"testing"
+
+	"thousand/internal/engine"
+)
+
+func findEvent(events []Event, typ string) (EventPayload, bool) {
+	for _, e := range events {
+		if e.Type == typ {
+			if data, ok := e.Data.(EventPayload); ok {
+				return data, true
+			}
+		}
+	}
+	return EventPayload{}, false
+}
+
+func TestKittyRevealedEventAndView(t *testing.T) {
+	r := engine.TisyachaPreset()
+	r.KittyFaceUp = true
+	g := newTestGame(t, r, 1)
+	engine.DealRound(&g)
+
+	bidder := g.Round.BidTurn
+	if err := engine.ApplyAction(&g, bidder, engine.Action{Type: engine.ActionBid, Bid: r.BidMin}); err != nil {
+		t.Fatalf("bid failed: %v", err)
+	}
+	var prev engine.GameState
+	last := -1
+	for g.Round.Phase == engine.PhaseBidding {
+		prev = g.Clone()
+		last = g.Round.BidTurn
+		if err := engine.ApplyAction(&g, last, engine.Action{Type: engine.ActionPass}); err != nil {
+			t.Fatalf("pass failed: %v", err)
+		}
+	}
+
+	data, ok := findEvent(buildEvents(prev, g, last, engine.Action{Type: engine.ActionPass}), "kitty_revealed")
+	if !ok {
+		t.Fatalf("expected kitty_revealed event")
+	}
+	if data.Player != bidder || len(data.Cards) != r.KittySize {
+		t.Fatalf("unexpected kitty_revealed payload: %+v", data)
+	}
+	for viewer := 0; viewer < r.Players; viewer++ {
+		if view := BuildGameView(g, viewer, "s"); len(view.Round.Kitty) != r.KittySize {
+			t.Fatalf("viewer %d should see the kitty, got %d cards", viewer, len(view.Round.Kitty))
+		}
+	}
+}
+
+func TestAllPassedEvents(t *testing.T) {
+	for _, tc := range []struct {
+		policy engine.AllPassPolicy
+		reason string
+	}{
+		{engine.AllPassRedeal, "redeal"},
+		{engine.AllPassForcedBid, "forced_bid"},
+		{engine.AllPassRaspasy, "raspasy"},
+	} {
+		r := engine.TisyachaPreset()
+		r.AllPassPolicy = tc.policy
+		g := newTestGame(t, r, 1)
+		engine.DealRound(&g)
+
+		var events []Event
+		for g.Round.Phase == engine.PhaseBidding {
+			prev := g.Clone()
+			player := g.Round.BidTurn
+			action := engine.Action{Type: engine.ActionPass}
+			if err := engine.ApplyAction(&g, player, action); err != nil {
+				t.Fatalf("pass failed: %v", err)
+			}
+			events = buildEvents(prev, g, player, action)
+		}
+		data, ok := findEvent(events, "all_passed")
+		if !ok || data.Reason != tc.reason {
+			t.Fatalf("expected all_passed with reason %q, got %+v", tc.reason, data)
+		}
+	}
+}
+
+func TestRedealRequestedEvent(t *testing.T) {
+	r := engine.TisyachaPreset()
+	r.RedealHandBelow = 200
+	g := newTestGame(t, r, 1)
+	engine.DealRound(&g)
+	player := g.Round.BidTurn
+	prev := g.Clone()
+	action := engine.Action{Type: engine.ActionRequestRedeal}
+	if err := engine.ApplyAction(&g, player, action); err != nil {
+		t.Fatalf("redeal failed: %v", err)
+	}
+
+	events := buildEvents(prev, g, player, action)
+	data, ok := findEvent(events, "redeal_requested")
+	if !ok || data.Reason != "weak_hand" || len(data.Cards) != r.DealHandSize {
+		t.Fatalf("unexpected redeal_requested payload: %+v", data)
+	}
+	if _, ok := findEvent(events, "round_scored"); ok {
+		t.Fatalf("redeal should not report a scored round")
+	}
+}
+
+// A plain struct copy of the game shares the players' hands and tricks
+// with the live state, so events built from it miss the trick that was just
+// won. The session snapshots with Clone for that reason.
+func TestSnapshotMustNotAliasState(t *testing.T) {
+	r := engine.TisyachaPreset()
+	g := newTestGame(t, r, 1)
+	engine.DealRound(&g)
+	if err := engine.ApplyAction(&g, g.Round.BidTurn, engine.Action{Type: engine.ActionBid, Bid: r.BidMin}); err != nil {
+		t.Fatalf("bid failed: %v", err)
+	}
+	for step := 0; ; step++ {
+		if step > 100 {
+			t.Fatalf("no trick completed")
+		}
+		player, _ := engine.CurrentPlayer(g)
+		action := fallbackAction(g, player, engine.LegalActions(g, player))
+		lastCard := g.Round.Phase == engine.PhasePlayTricks && len(g.Round.TrickCards) == len(g.Round.TrickOrder)-1
+		shallow := g
+		snapshot := g.Clone()
+		if err := engine.ApplyAction(&g, player, action); err != nil {
+			t.Fatalf("action failed: %v", err)
+		}
+		if !lastCard {
+			continue
+		}
+		if _, ok := findEvent(buildEvents(shallow, g, player, action), "trick_won"); ok {
+			t.Fatalf("expected the shallow copy to alias the won trick")
+		}
+		if _, ok := findEvent(buildEvents(snapshot, g, player, action), "trick_won"); !ok {
+			t.Fatalf("expected trick_won from a cloned snapshot")
+		}
+		return
+	}
+}
+
+func TestContractRaisedEvent(t *testing.T) {
+	r := engine.RaisePreset()
+	g := newTestGame(t, r, 1)
+	engine.DealRound(&g)
+	bidder := g.Round.BidTurn
+	if err := engine.ApplyAction(&g, bidder, engine.Action{Type: engine.ActionBid, Bid: r.BidMin}); err != nil {
+		t.Fatalf("bid failed: %v", err)
+	}
+	for g.Round.Phase == engine.PhaseBidding {
+		if err := engine.ApplyAction(&g, g.Round.BidTurn, engine.Action{Type: engine.ActionPass}); err != nil {
+			t.Fatalf("pass failed: %v", err)
+		}
+	}
+	if err := engine.ApplyAction(&g, bidder, engine.Action{Type: engine.ActionTakeKitty}); err != nil {
+		t.Fatalf("take kitty failed: %v", err)
+	}
+	if view := BuildGameView(g, bidder, "s"); view.Round.Phase != "Raise" {
+		t.Fatalf("expected Raise phase in the view, got %s", view.Round.Phase)
+	}
+
+	dto := ActionDTO{Type: "raise_contract", Bid: r.BidMin + r.BidStep}
+	action, err := dto.ToEngine()
+	if err != nil {
+		t.Fatalf("raise_contract dto: %v", err)
+	}
+	prev := g.Clone()
+	if err := engine.ApplyAction(&g, bidder, action); err != nil {
+		t.Fatalf("raise failed: %v", err)
+	}
+	data, ok := findEvent(buildEvents(prev, g, bidder, action), "contract_raised")
+	if !ok || data.Player != bidder || data.Bid != r.BidMin+r.BidStep {
+		t.Fatalf("unexpected contract_raised payload: %+v", data)
+	}
+}
+
+func TestContractKeptEvent(t *testing.T) {
+	prev := newTestGame(t, engine.RaisePreset(), 1)
+	prev.Round.Phase = engine.PhaseRaise
+	prev.Round.BidWinner = 1
+	prev.Round.BidValue = 100
+	next := prev.Clone()
+	action := engine.Action{Type: engine.ActionPass}
+	if err := engine.ApplyAction(&next, 1, action); err != nil {
+		t.Fatalf("keep failed: %v", err)
+	}
+	events := buildEvents(prev, next, 1, action)
+	if data, ok := findEvent(events, "contract_kept"); !ok || data.Bid != 100 {
+		t.Fatalf("unexpected contract_kept payload: %+v", data)
+	}
+	if _, ok := findEvent(events, "bid_passed"); ok {
+		t.Fatalf("keeping the contract is not a bidding pass")
+	}
+}
+
+func TestDefenderCappedEvent(t *testing.T) {
+	prev := newTestGame(t, engine.TisyachaPreset(), 1)
+	prev.Round.Phase = engine.PhasePlayTricks
+	next := prev.Clone()
+	next.Round.Phase = engine.PhaseDeal
+	next.LastRoundEffects.Capped = []engine.ScoreChange{{Player: 2, Points: 15}}
+	card := engine.Card{Suit: engine.SuitHearts, Rank: engine.RankA}
+
+	data, ok := findEvent(buildEvents(prev, next, 0, engine.Action{Type: engine.ActionPlayCard, Card: &card}), "defender_capped")
+	if !ok || data.Player != 2 || data.Value != 15 {
+		t.Fatalf("unexpected defender_capped payload: %+v", data)
+	}
+}
+
+func TestContraEvents(t *testing.T) {
+	g := newTestGame(t, engine.KontraPreset(), 1)
+	g.Round.Phase = engine.PhaseContra
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 100
+	g.Round.ContraBy = -1
+	g.Round.ContraTurn = 1
+
+	prev := g.Clone()
+	if err := engine.ApplyAction(&g, 1, engine.Action{Type: engine.ActionPass}); err != nil {
+		t.Fatalf("pass failed: %v", err)
+	}
+	if _, ok := findEvent(buildEvents(prev, g, 1, engine.Action{Type: engine.ActionPass}), "contra_passed"); !ok {
+		t.Fatalf("expected contra_passed event")
+	}
+
+	prev = g.Clone()
+	if err := engine.ApplyAction(&g, 2, engine.Action{Type: engine.ActionContra}); err != nil {
+		t.Fatalf("contra failed: %v", err)
+	}
+	data, ok := findEvent(buildEvents(prev, g, 2, engine.Action{Type: engine.ActionContra}), "contra_declared")
+	if !ok || data.Player != 2 || data.Value != 2 {
+		t.Fatalf("expected contra_declared by 2 at x2, got %+v", data)
+	}
+	if view := BuildGameView(g, 0, "s"); view.Round.Phase != "Contra" || view.Round.Multiplier != 2 {
+		t.Fatalf("unexpected round view: %+v", view.Round)
+	}
+}
+
+func TestRospisSettledEvent(t *testing.T) {
+	g := newTestGame(t, engine.TisyachaPreset(), 1)
+	g.Round.Phase = engine.PhasePlayTricks
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 120
+
+	prev := g.Clone()
+	if err := engine.ApplyAction(&g, 0, engine.Action{Type: engine.ActionRospis}); err != nil {
+		t.Fatalf("rospis failed: %v", err)
+	}
+	data, ok := findEvent(buildEvents(prev, g, 0, engine.Action{Type: engine.ActionRospis}), "rospis_settled")
+	if !ok || data.Player != 0 {
+		t.Fatalf("expected rospis_settled for bidder 0, got %+v", data)
+	}
+	if len(data.Points) != 3 || data.Points[0] != -120 || data.Points[1] != 60 || data.Points[2] != 60 {
+		t.Fatalf("unexpected settlement points: %v", data.Points)
+	}
+}
+
+func TestRospisReportsCappedDefender(t *testing.T) {
+	r := engine.TisyachaPreset()
+	r.DefenderScoreCap = 800
+	g := newTestGame(t, r, 1)
+	g.Round.Phase = engine.PhasePlayTricks
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 200
+	g.Players[2].GameScore = 790
+
+	prev := g.Clone()
+	if err := engine.ApplyAction(&g, 0, engine.Action{Type: engine.ActionRospis}); err != nil {
+		t.Fatalf("rospis failed: %v", err)
+	}
+	data, ok := findEvent(buildEvents(prev, g, 0, engine.Action{Type: engine.ActionRospis}), "defender_capped")
+	if !ok || data.Player != 2 || data.Value != 90 {
+		t.Fatalf("expected defender_capped for player 2, got %+v", data)
+	}
+}
+
+func TestRospisCanEndTheGame(t *testing.T) {
+	g := newTestGame(t, engine.TisyachaPreset(), 1)
+	g.Round.Phase = engine.PhasePlayTricks
+	g.Round.BidWinner = 0
+	g.Round.BidValue = 120
+	g.Players[2].GameScore = 950
+
+	prev := g.Clone()
+	if err := engine.ApplyAction(&g, 0, engine.Action{Type: engine.ActionRospis}); err != nil {
+		t.Fatalf("rospis failed: %v", err)
+	}
+	events := buildEvents(prev, g, 0, engine.Action{Type: engine.ActionRospis})
+	if _, ok := findEvent(events, "rospis_settled"); !ok {
+		t.Fatalf("expected rospis_settled, got %+v", events)
+	}
+	data, ok := findEvent(events, "game_ended")
+	if !ok || data.Player != 2 || len(data.Standings) != 3 || data.Standings[0].Player != 2 {
+		t.Fatalf("expected game_ended for defender 2, got %+v", data)
+	}
+}
+
+func TestGameEndedCarriesStandings(t *testing.T) {
+	prev := newTestGame(t, engine.TisyachaPreset(), 1)
+	prev.Round.Phase = engine.PhasePlayTricks
+	next := prev.Clone()
+	next.Round.Phase = engine.PhaseGameOver
+	next.Players[0].GameScore = 400
+	next.Players[1].GameScore = 1010
+	next.Players[2].GameScore = 1020
+	next.LastRoundEffects = engine.RoundEffects{Winner: 1, HasWinner: true, WinReason: engine.WinBidder, Contenders: []int{1, 2}}
+
+	card := engine.Card{Suit: engine.SuitHearts, Rank: engine.Rank9}
+	data, ok := findEvent(buildEvents(prev, next, 0, engine.Action{Type: engine.ActionPlayCard, Card: &card}), "game_ended")
+	if !ok || data.Player != 1 || data.Reason != "bidder" {
+		t.Fatalf("expected game_ended for bidder 1, got %+v", data)
+	}
+	want := []Standing{{Player: 1, Score: 1010}, {Player: 2, Score: 1020}, {Player: 0, Score: 400}}
+	if len(data.Standings) != len(want) {
+		t.Fatalf("unexpected standings: %+v", data.Standings)
+	}
+	for i := range want {
+		if data.Standings[i] != want[i] {
+			t.Fatalf("unexpected standings: %+v", data.Standings)
+		}
+	}
+}
+
+func TestStandingsOrderWinnerThenScoreThenSeat(t *testing.T) {
+	r := engine.TisyachaPreset()
+	r.Players = 4
+	r.DealerSitsOut = true
+	g := newTestGame(t, r, 1)
+	for i, score := range []int{900, 1000, 900, 1000} {
+		g.Players[i].GameScore = score
+	}
+	cases := []struct {
+		effects engine.RoundEffects
+		want    []int
+	}{
+		{engine.RoundEffects{Winner: 3, HasWinner: true}, []int{3, 1, 0, 2}},
+		{engine.RoundEffects{Winner: 0, HasWinner: true}, []int{0, 1, 3, 2}},
+		{engine.RoundEffects{}, []int{1, 3, 0, 2}},
+	}
+	for _, c := range cases {
+		g.LastRoundEffects = c.effects
+		got := buildStandings(g)
+		for i, seat := range c.want {
+			if got[i].Player != seat {
+				t.Fatalf("winner %+v: expected seats %v, got %+v", c.effects, c.want, got)
+			}
+		}
+	}
+}
+
+// playOutRound applies fallback actions until the current deal ends and
+// returns every event built along the way.
+func playOutRound(t *testing.T, g *engine.GameState) []Event {
+	t.Helper()
+	var events []Event
+	for step := 0; step < 200; step++ {
+		if g.Round.Phase == engine.PhaseGameOver || (g.Round.Phase == engine.PhaseDeal && !g.Round.HandsDealt) {
+			return events
+		}
+		player, ok := engine.CurrentPlayer(*g)
+		if !ok {
+			t.Fatalf("no current player in phase %v", g.Round.Phase)
+		}
+		prev := g.Clone()
+		action := fallbackAction(*g, player, engine.LegalActions(*g, player))
+		if err := engine.ApplyAction(g, player, action); err != nil {
+			t.Fatalf("action failed: %v", err)
+		}
+		events = append(events, buildEvents(prev, *g, player, action)...)
+	}
+	t.Fatalf("round did not end")
+	return nil
+}
+
+func TestDealCommitmentRevealedAndVerified(t *testing.T) {
+	r := engine.TisyachaPreset()
+	g := newTestGame(t, r, 5)
+	g.Shuffler = engine.StableShufflerV2{}
+	engine.DealRound(&g)
+	announced := dealCommittedEvents(g)
+	if len(announced) != 1 {
+		t.Fatalf("expected one deal_committed event, got %+v", announced)
+	}
+	committed, ok := announced[0].Data.(EventPayload)
+	if !ok || committed.Commitment == "" || committed.Shuffler != "pcg32-v2" {
+		t.Fatalf("unexpected deal_committed payload: %+v", committed)
+	}
+
+	revealed, found := findEvent(playOutRound(t, &g), "deal_revealed")
+	if !found {
+		t.Fatalf("expected the deal to be revealed when the round ends")
+	}
+	if revealed.Commitment != committed.Commitment {
+		t.Fatalf("revealed commitment %s does not match %s", revealed.Commitment, committed.Commitment)
+	}
+	seed, err := strconv.ParseInt(revealed.Seed, 10, 64)
+	if err != nil {
+		t.Fatalf("bad revealed seed %q: %v", revealed.Seed, err)
+	}
+	deck := make([]engine.Card, 0, len(revealed.Cards))
+	for _, dto := range revealed.Cards {
+		c, err := dto.toEngine()
+		if err != nil {
+			t.Fatalf("bad revealed card %+v: %v", dto, err)
+		}
+		deck = append(deck, c)
+	}
+	if engine.DealCommitment(seed, deck) != committed.Commitment {
+		t.Fatalf("revealed seed and deck do not match the commitment")
+	}
+	if _, err := engine.VerifyDeal(r, engine.StableShufflerV2{}, seed, committed.Commitment); err != nil {
+		t.Fatalf("client verification failed: %v", err)
+	}
+
+	swapped := append([]engine.Card(nil), deck...)
+	swapped[0], swapped[1] = swapped[1], swapped[0]
+	if engine.DealCommitment(seed, swapped) == committed.Commitment {
+		t.Fatalf("a swapped deck should not match the commitment")
+	}
+	if engine.DealCommitment(seed+1, deck) == committed.Commitment {
+		t.Fatalf("a changed seed should not match the commitment")
+	}
+}
+
+func TestCryptoDealsAreNotAnnounced(t *testing.T) {
+	g := newTestGame(t, engine.TisyachaPreset(), 5)
+	g.Shuffler = engine.CryptoShuffler{}
+	engine.DealRound(&g)
+	if events := dealCommittedEvents(g); len(events) != 0 {
+		t.Fatalf("crypto deal should not be committed, got %+v", events)
+	}
+	if _, found := findEvent(playOutRound(t, &g), "deal_revealed"); found {
+		t.Fatalf("crypto deal should not be revealed")
+	}
+}
@@ -0,0 +1,169 @@
+package server
+
+import "thousand/internal/engine"
+
+// RoundSummaryView is one finished round of the game record.
+type RoundSummaryView struct {
+	Number        int                  `json:"number"`
+	Dealer        int                  `json:"dealer"`
+	Outcome       string               `json:"outcome"`
+	Bids          []BidRecordView      `json:"bids"`
+	Contract      int                  `json:"contract"`
+	ContractValue int                  `json:"contractValue"`
+	Multiplier    int                  `json:"multiplier"`
+	ForcedBid     bool                 `json:"forcedBid"`
+	Raspasy       bool                 `json:"raspasy"`
+	Trump         *string              `json:"trump,omitempty"`
+	Kitty         []CardDTO            `json:"kitty"`
+	Snos          []CardDTO            `json:"snos"`
+	Tricks        []TrickRecordView    `json:"tricks"`
+	Marriages     []MarriageRecordView `json:"marriages"`
+	Points        []int                `json:"points,omitempty"`
+	Effects       RoundEffectsView     `json:"effects"`
+	Scores        []int                `json:"scores"`
+}
+
+type BidRecordView struct {
+	Player int  `json:"player"`
+	Bid    int  `json:"bid,omitempty"`
+	Pass   bool `json:"pass,omitempty"`
+}
+
+type PlayView struct {
+	Player int     `json:"player"`
+	Card   CardDTO `json:"card"`
+}
+
+type TrickRecordView struct {
+	Plays  []PlayView `json:"plays"`
+	Winner int        `json:"winner"`
+}
+
+type MarriageRecordView struct {
+	Player int    `json:"player"`
+	Suit   string `json:"suit,omitempty"`
+	Ace    bool   `json:"ace,omitempty"`
+	Points int    `json:"points"`
+}
+
+type ScoreChangeView struct {
+	Player int `json:"player"`
+	Points int `json:"points"`
+}
+
+type RoundEffectsView struct {
+	Bolts         []int             `json:"bolts,omitempty"`
+	BoltPenalties []int             `json:"boltPenalties,omitempty"`
+	BarrelEnter   []int             `json:"barrelEnter,omitempty"`
+	BarrelExit    []int             `json:"barrelExit,omitempty"`
+	BarrelPenalty []int             `json:"barrelPenalty,omitempty"`
+	BarrelPushed  []int             `json:"barrelPushed,omitempty"`
+	Capped        []ScoreChangeView `json:"capped,omitempty"`
+	Rospis        []ScoreChangeView `json:"rospis,omitempty"`
+	Dumped        []int             `json:"dumped,omitempty"`
+	Winner        int               `json:"winner"`
+	HasWinner     bool              `json:"hasWinner"`
+	WinReason     string            `json:"winReason,omitempty"`
+	Contenders    []int             `json:"contenders,omitempty"`
+}
+
+// buildHistoryView converts the game record, oldest round first.
+func buildHistoryView(g engine.GameState) []RoundSummaryView {
+	out := make([]RoundSummaryView, 0, len(g.History))
+	for _, r := range g.History {
+		out = append(out, buildRoundSummaryView(r))
+	}
+	return out
+}
+
+func buildRoundSummaryView(r engine.RoundSummary) RoundSummaryView {
+	v := RoundSummaryView{
+		Number:        r.Number,
+		Dealer:        r.Dealer,
+		Outcome:       outcomeToString(r.Outcome),
+		Bids:          make([]BidRecordView, 0, len(r.Bids)),
+		Contract:      r.Contract,
+		ContractValue: r.ContractValue,
+		Multiplier:    r.Multiplier,
+		ForcedBid:     r.ForcedBid,
+		Raspasy:       r.Raspasy,
+		Kitty:         cardsToDTO(r.Kitty),
+		Snos:          cardsToDTO(r.Snos),
+		Tricks:        make([]TrickRecordView, 0, len(r.Tricks)),
+		Marriages:     make([]MarriageRecordView, 0, len(r.Marriages)),
+		Points:        r.Points,
+		Effects:       buildRoundEffectsView(r.Effects),
+		Scores:        r.Scores,
+	}
+	if r.Trump != nil {
+		s := suitToString(*r.Trump)
+		v.Trump = &s
+	}
+	for _, b := range r.Bids {
+		v.Bids = append(v.Bids, BidRecordView{Player: b.Player, Bid: b.Bid, Pass: b.Pass})
+	}
+	for _, t := range r.Tricks {
+		plays := make([]PlayView, 0, len(t.Plays))
+		for _, p := range t.Plays {
+			plays = append(plays, PlayView{Player: p.Player, Card: cardToDTO(p.Card)})
+		}
+		v.Tricks = append(v.Tricks, TrickRecordView{Plays: plays, Winner: t.Winner})
+	}
+	for _, m := range r.Marriages {
+		mv := MarriageRecordView{Player: m.Player, Ace: m.Ace, Points: m.Points}
+		if !m.Ace {
+			mv.Suit = suitToString(m.Suit)
+		}
+		v.Marriages = append(v.Marriages, mv)
+	}
+	return v
+}
+
+func buildRoundEffectsView(e engine.RoundEffects) RoundEffectsView {
+	return RoundEffectsView{
+		Bolts:         e.Bolts,
+		BoltPenalties: e.BoltPenalties,
+		BarrelEnter:   e.BarrelEnter,
+		BarrelExit:    e.BarrelExit,
+		BarrelPenalty: e.BarrelPenalty,
+		BarrelPushed:  e.BarrelPushed,
+		Capped:        scoreChangesToView(e.Capped),
+		Rospis:        scoreChangesToView(e.Rospis),
+		Dumped:        e.Dumped,
+		Winner:        e.Winner,
+		HasWinner:     e.HasWinner,
+		WinReason:     winReasonToString(e.WinReason),
+		Contenders:    e.Contenders,
+	}
+}
+
+func scoreChangesToView(changes []engine.ScoreChange) []ScoreChangeView {
+	var out []ScoreChangeView
+	for _, c := range changes {
+		out = append(out, ScoreChangeView{Player: c.Player, Points: c.Points})
+	}
+	return out
+}
+
+func cardsToDTO(cards []engine.Card) []CardDTO {
+	out := make([]CardDTO, 0, len(cards))
+	for _, c := range cards {
+		out = append(out, cardToDTO(c))
+	}
+	return out
+}
+
+func outcomeToString(o engine.RoundOutcome) string {
+	switch o {
+	case engine.OutcomeScored:
+		return "scored"
+	case engine.OutcomeRospis:
+		return "rospis"
+	case engine.OutcomeAllPassed:
+		return "all_passed"
+	case engine.OutcomeRedealRequested:
+		return "redeal_requested"
+	default:
+		return ""
+	}
+}
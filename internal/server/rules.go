@@ -0,0 +1,279 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"thousand/internal/engine"
+)
+
+// RulesView mirrors engine.Rules field for field. Card points are keyed by
+// rank ("A", "10", ...) and marriage points by suit ("H", "D", ...).
+type RulesView struct {
+	Players                int            `json:"players"`
+	DeckRanks              []string       `json:"deckRanks"`
+	DealHandSize           int            `json:"dealHandSize"`
+	PlayHandSize           int            `json:"playHandSize"`
+	KittySize              int            `json:"kittySize"`
+	SnosCards              int            `json:"snosCards"`
+	BidMin                 int            `json:"bidMin"`
+	BidStep                int            `json:"bidStep"`
+	MaxBid                 int            `json:"maxBid"`
+	WinScore               int            `json:"winScore"`
+	MustFollowSuit         bool           `json:"mustFollowSuit"`
+	MustTrumpIfVoid        bool           `json:"mustTrumpIfVoid"`
+	MustOverTrump          bool           `json:"mustOverTrump"`
+	ContractScoresAsBid    bool           `json:"contractScoresAsBid"`
+	ContractFailPenaltyBid bool           `json:"contractFailPenaltyBid"`
+	MarriageRequiresTrick  bool           `json:"marriageRequiresTrick"`
+	AceMarriageEnabled     bool           `json:"aceMarriageEnabled"`
+	BarrelThreshold        int            `json:"barrelThreshold"`
+	BarrelTarget           int            `json:"barrelTarget"`
+	BarrelAttempts         int            `json:"barrelAttempts"`
+	BoltPenalty            int            `json:"boltPenalty"`
+	BoltEvery              int            `json:"boltEvery"`
+	DumpThreshold          int            `json:"dumpThreshold"`
+	DumpNegativeThreshold  int            `json:"dumpNegativeThreshold"`
+	DealerSitsOut          bool           `json:"dealerSitsOut"`
+	DealerScoring          string         `json:"dealerScoring"`
+	DeadHandSize           int            `json:"deadHandSize"`
+	SnosToDeadHand         bool           `json:"snosToDeadHand"`
+	AllowContractRaise     bool           `json:"allowContractRaise"`
+	KittyFaceUp            bool           `json:"kittyFaceUp"`
+	AllPassPolicy          string         `json:"allPassPolicy"`
+	ForcedBidSeat          int            `json:"forcedBidSeat"`
+	ForcedBidValue         int            `json:"forcedBidValue"`
+	RaspasyKitty           string         `json:"raspasyKitty"`
+	MarriageOnLeadOnly     bool           `json:"marriageOnLeadOnly"`
+	BidCapWithoutMarriage  int            `json:"bidCapWithoutMarriage"`
+	RedealFourNines        bool           `json:"redealFourNines"`
+	RedealHandBelow        int            `json:"redealHandBelow"`
+	RedealKittyBelow       int            `json:"redealKittyBelow"`
+	BarrelPolicy           string         `json:"barrelPolicy"`
+	BarrelPushPenalty      int            `json:"barrelPushPenalty"`
+	DefenderScoreCap       int            `json:"defenderScoreCap"`
+	BarrelNeedsContract    bool           `json:"barrelNeedsContract"`
+	PointsRounding         string         `json:"pointsRounding"`
+	CardPoints             map[string]int `json:"cardPoints"`
+	MarriagePoints         map[string]int `json:"marriagePoints"`
+	AceMarriagePoints      int            `json:"aceMarriagePoints"`
+	AllowContra            bool           `json:"allowContra"`
+	RospisPolicy           string         `json:"rospisPolicy"`
+	RospisFixedPoints      int            `json:"rospisFixedPoints"`
+	RospisRefusable        bool           `json:"rospisRefusable"`
+	TiebreakPolicy         string         `json:"tiebreakPolicy"`
+}
+
+// Enum names are listed in the order of the engine constants.
+var (
+	dealerScoringNames  = []string{"nothing", "kitty"}
+	allPassPolicyNames  = []string{"redeal", "forced_bid", "raspasy"}
+	raspasyKittyNames   = []string{"set_aside", "first_trick"}
+	barrelPolicyNames   = []string{"single_owner", "push_off_penalty", "shared"}
+	pointsRoundingNames = []string{"none", "nearest5", "ceil5"}
+	rospisPolicyNames   = []string{"half", "half_rounded", "fixed"}
+	tiebreakPolicyNames = []string{"bidder_first", "highest_score", "play_on"}
+)
+
+func enumName(names []string, v int) string {
+	if v < 0 || v >= len(names) {
+		return "?"
+	}
+	return names[v]
+}
+
+func parseEnum(field string, names []string, s string) (int, error) {
+	for i, name := range names {
+		if name == s {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%s: unknown value %q", field, s)
+}
+
+func buildRulesView(r engine.Rules) RulesView {
+	ranks := make([]string, 0, len(r.DeckRanks))
+	for _, rank := range r.DeckRanks {
+		ranks = append(ranks, rankToString(rank))
+	}
+	cardPoints := make(map[string]int, len(r.CardPoints))
+	for rank, v := range r.CardPoints {
+		cardPoints[rankToString(rank)] = v
+	}
+	marriagePoints := make(map[string]int, len(r.MarriagePoints))
+	for suit, v := range r.MarriagePoints {
+		marriagePoints[suitToString(suit)] = v
+	}
+	return RulesView{
+		Players:                r.Players,
+		DeckRanks:              ranks,
+		DealHandSize:           r.DealHandSize,
+		PlayHandSize:           r.PlayHandSize,
+		KittySize:              r.KittySize,
+		SnosCards:              r.SnosCards,
+		BidMin:                 r.BidMin,
+		BidStep:                r.BidStep,
+		MaxBid:                 r.MaxBid,
+		WinScore:               r.WinScore,
+		MustFollowSuit:         r.MustFollowSuit,
+		MustTrumpIfVoid:        r.MustTrumpIfVoid,
+		MustOverTrump:          r.MustOverTrump,
+		ContractScoresAsBid:    r.ContractScoresAsBid,
+		ContractFailPenaltyBid: r.ContractFailPenaltyBid,
+		MarriageRequiresTrick:  r.MarriageRequiresTrick,
+		AceMarriageEnabled:     r.AceMarriageEnabled,
+		BarrelThreshold:        r.BarrelThreshold,
+		BarrelTarget:           r.BarrelTarget,
+		BarrelAttempts:         r.BarrelAttempts,
+		BoltPenalty:            r.BoltPenalty,
+		BoltEvery:              r.BoltEvery,
+		DumpThreshold:          r.DumpThreshold,
+		DumpNegativeThreshold:  r.DumpNegativeThreshold,
+		DealerSitsOut:          r.DealerSitsOut,
+		DealerScoring:          enumName(dealerScoringNames, int(r.DealerScoring)),
+		DeadHandSize:           r.DeadHandSize,
+		SnosToDeadHand:         r.SnosToDeadHand,
+		AllowContractRaise:     r.AllowContractRaise,
+		KittyFaceUp:            r.KittyFaceUp,
+		AllPassPolicy:          enumName(allPassPolicyNames, int(r.AllPassPolicy)),
+		ForcedBidSeat:          r.ForcedBidSeat,
+		ForcedBidValue:         r.ForcedBidValue,
+		RaspasyKitty:           enumName(raspasyKittyNames, int(r.RaspasyKitty)),
+		MarriageOnLeadOnly:     r.MarriageOnLeadOnly,
+		BidCapWithoutMarriage:  r.BidCapWithoutMarriage,
+		RedealFourNines:        r.RedealFourNines,
+		RedealHandBelow:        r.RedealHandBelow,
+		RedealKittyBelow:       r.RedealKittyBelow,
+		BarrelPolicy:           enumName(barrelPolicyNames, int(r.BarrelPolicy)),
+		BarrelPushPenalty:      r.BarrelPushPenalty,
+		DefenderScoreCap:       r.DefenderScoreCap,
+		BarrelNeedsContract:    r.BarrelNeedsContract,
+		PointsRounding:         enumName(pointsRoundingNames, int(r.PointsRounding)),
+		CardPoints:             cardPoints,
+		MarriagePoints:         marriagePoints,
+		AceMarriagePoints:      r.AceMarriagePoints,
+		AllowContra:            r.AllowContra,
+		RospisPolicy:           enumName(rospisPolicyNames, int(r.RospisPolicy)),
+		RospisFixedPoints:      r.RospisFixedPoints,
+		RospisRefusable:        r.RospisRefusable,
+		TiebreakPolicy:         enumName(tiebreakPolicyNames, int(r.TiebreakPolicy)),
+	}
+}
+
+func (v RulesView) toEngine() (engine.Rules, error) {
+	r := engine.Rules{
+		Players:                v.Players,
+		DealHandSize:           v.DealHandSize,
+		PlayHandSize:           v.PlayHandSize,
+		KittySize:              v.KittySize,
+		SnosCards:              v.SnosCards,
+		BidMin:                 v.BidMin,
+		BidStep:                v.BidStep,
+		MaxBid:                 v.MaxBid,
+		WinScore:               v.WinScore,
+		MustFollowSuit:         v.MustFollowSuit,
+		MustTrumpIfVoid:        v.MustTrumpIfVoid,
+		MustOverTrump:          v.MustOverTrump,
+		ContractScoresAsBid:    v.ContractScoresAsBid,
+		ContractFailPenaltyBid: v.ContractFailPenaltyBid,
+		MarriageRequiresTrick:  v.MarriageRequiresTrick,
+		AceMarriageEnabled:     v.AceMarriageEnabled,
+		BarrelThreshold:        v.BarrelThreshold,
+		BarrelTarget:           v.BarrelTarget,
+		BarrelAttempts:         v.BarrelAttempts,
+		BoltPenalty:            v.BoltPenalty,
+		BoltEvery:              v.BoltEvery,
+		DumpThreshold:          v.DumpThreshold,
+		DumpNegativeThreshold:  v.DumpNegativeThreshold,
+		DealerSitsOut:          v.DealerSitsOut,
+		DeadHandSize:           v.DeadHandSize,
+		SnosToDeadHand:         v.SnosToDeadHand,
+		AllowContractRaise:     v.AllowContractRaise,
+		KittyFaceUp:            v.KittyFaceUp,
+		ForcedBidSeat:          v.ForcedBidSeat,
+		ForcedBidValue:         v.ForcedBidValue,
+		MarriageOnLeadOnly:     v.MarriageOnLeadOnly,
+		BidCapWithoutMarriage:  v.BidCapWithoutMarriage,
+		RedealFourNines:        v.RedealFourNines,
+		RedealHandBelow:        v.RedealHandBelow,
+		RedealKittyBelow:       v.RedealKittyBelow,
+		BarrelPushPenalty:      v.BarrelPushPenalty,
+		DefenderScoreCap:       v.DefenderScoreCap,
+		BarrelNeedsContract:    v.BarrelNeedsContract,
+		AceMarriagePoints:      v.AceMarriagePoints,
+		AllowContra:            v.AllowContra,
+		RospisFixedPoints:      v.RospisFixedPoints,
+		RospisRefusable:        v.RospisRefusable,
+		CardPoints:             make(map[engine.Rank]int, len(v.CardPoints)),
+		MarriagePoints:         make(map[engine.Suit]int, len(v.MarriagePoints)),
+	}
+	for _, s := range v.DeckRanks {
+		rank, err := parseRank(s)
+		if err != nil {
+			return engine.Rules{}, fmt.Errorf("deckRanks: %w", err)
+		}
+		r.DeckRanks = append(r.DeckRanks, rank)
+	}
+	for s, pts := range v.CardPoints {
+		rank, err := parseRank(s)
+		if err != nil {
+			return engine.Rules{}, fmt.Errorf("cardPoints: %w", err)
+		}
+		r.CardPoints[rank] = pts
+	}
+	for s, pts := range v.MarriagePoints {
+		suit, err := parseSuit(s)
+		if err != nil {
+			return engine.Rules{}, fmt.Errorf("marriagePoints: %w", err)
+		}
+		r.MarriagePoints[suit] = pts
+	}
+
+	n, err := parseEnum("dealerScoring", dealerScoringNames, v.DealerScoring)
+	if err != nil {
+		return engine.Rules{}, err
+	}
+	r.DealerScoring = engine.DealerScoring(n)
+	if n, err = parseEnum("allPassPolicy", allPassPolicyNames, v.AllPassPolicy); err != nil {
+		return engine.Rules{}, err
+	}
+	r.AllPassPolicy = engine.AllPassPolicy(n)
+	if n, err = parseEnum("raspasyKitty", raspasyKittyNames, v.RaspasyKitty); err != nil {
+		return engine.Rules{}, err
+	}
+	r.RaspasyKitty = engine.RaspasyKitty(n)
+	if n, err = parseEnum("barrelPolicy", barrelPolicyNames, v.BarrelPolicy); err != nil {
+		return engine.Rules{}, err
+	}
+	r.BarrelPolicy = engine.BarrelPolicy(n)
+	if n, err = parseEnum("pointsRounding", pointsRoundingNames, v.PointsRounding); err != nil {
+		return engine.Rules{}, err
+	}
+	r.PointsRounding = engine.PointsRounding(n)
+	if n, err = parseEnum("rospisPolicy", rospisPolicyNames, v.RospisPolicy); err != nil {
+		return engine.Rules{}, err
+	}
+	r.RospisPolicy = engine.RospisPolicy(n)
+	if n, err = parseEnum("tiebreakPolicy", tiebreakPolicyNames, v.TiebreakPolicy); err != nil {
+		return engine.Rules{}, err
+	}
+	r.TiebreakPolicy = engine.TiebreakPolicy(n)
+	return r, nil
+}
+
+// mergeRules applies a partial RulesView JSON object on top of base. Fields
+// missing from overrides keep the base value; point maps are merged key by
+// key. Unknown fields are rejected so typos do not pass silently.
+func mergeRules(base engine.Rules, overrides json.RawMessage) (engine.Rules, error) {
+	if len(overrides) == 0 {
+		return base, nil
+	}
+	view := buildRulesView(base)
+	dec := json.NewDecoder(bytes.NewReader(overrides))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&view); err != nil {
+		return engine.Rules{}, err
+	}
+	return view.toEngine()
+}
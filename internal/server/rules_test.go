@@ -0,0 +1,118 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"thousand/internal/engine"
+)
+
+func TestRulesViewRoundTrip(t *testing.T) {
+	for _, p := range engine.Presets() {
+		want := p.Rules()
+		got, err := buildRulesView(want).toEngine()
+		if err != nil {
+			t.Fatalf("%s: %v", p.Name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: round trip mismatch\n got %+v\nwant %+v", p.Name, got, want)
+		}
+	}
+}
+
+func TestMergeRulesOverridesFields(t *testing.T) {
+	raw := json.RawMessage(`{"winScore": 500, "boltPenalty": 50, "barrelThreshold": 400, "cardPoints": {"9": 1}, "allPassPolicy": "raspasy"}`)
+	r, err := mergeRules(engine.TisyachaPreset(), raw)
+	if err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+	if r.WinScore != 500 || r.BoltPenalty != 50 || r.BarrelThreshold != 400 {
+		t.Fatalf("overrides not applied: %+v", r)
+	}
+	if r.CardValue(engine.Rank9) != 1 || r.CardValue(engine.RankA) != 11 {
+		t.Fatalf("card points should merge key by key: %v", r.CardPoints)
+	}
+	if r.AllPassPolicy != engine.AllPassRaspasy {
+		t.Fatalf("expected raspasy policy, got %v", r.AllPassPolicy)
+	}
+	if r.BidMin != 80 || r.Players != 3 {
+		t.Fatalf("untouched fields should keep the preset values: %+v", r)
+	}
+}
+
+func TestMergeRulesRejectsUnknownFields(t *testing.T) {
+	if _, err := mergeRules(engine.TisyachaPreset(), json.RawMessage(`{"winPoints": 500}`)); err == nil {
+		t.Fatalf("expected an unknown field to be rejected")
+	}
+	if _, err := mergeRules(engine.TisyachaPreset(), json.RawMessage(`{"barrelPolicy": "sometimes"}`)); err == nil {
+		t.Fatalf("expected an unknown enum value to be rejected")
+	}
+}
+
+func TestStartGameValidatesOverrides(t *testing.T) {
+	s := &Session{}
+	s.startGame("tisyacha", json.RawMessage(`{"winScore": 500}`))
+	if s.started {
+		t.Fatalf("barrel threshold above the win score should be rejected")
+	}
+	s.startGame("tisyacha", json.RawMessage(`{"winScore": 500, "barrelThreshold": 400}`))
+	if !s.started || s.state.Rules.WinScore != 500 {
+		t.Fatalf("expected a quick game to 500, got %+v", s.state.Rules)
+	}
+}
+
+// dialSession serves s over a test websocket and returns the client end.
+func dialSession(t *testing.T, s *Session) *websocket.Conn {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		s.HandleConnection(conn)
+	}))
+	t.Cleanup(srv.Close)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestStartGameRepliesToOverflowingOverrides(t *testing.T) {
+	s := &Session{}
+	conn := dialSession(t, s)
+	msg := `{"type": "start_game", "ruleset": "tisyacha", "rules": {"maxBid": 9223372036854775807, "bidStep": 1, "winScore": 9223372036854775807}}`
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var reply ServerMessage
+	if err := conn.ReadJSON(&reply); err != nil {
+		t.Fatalf("no reply to start_game: %v", err)
+	}
+	if reply.Type != "error" || reply.Error == nil || reply.Error.Code != "invalid_rules" {
+		t.Fatalf("expected an invalid_rules error, got %+v", reply)
+	}
+	fields := map[string]bool{}
+	for _, f := range reply.Error.Fields {
+		fields[f.Field] = true
+	}
+	if !fields["MaxBid"] || !fields["WinScore"] {
+		t.Fatalf("expected MaxBid and WinScore reported, got %+v", reply.Error.Fields)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		t.Fatalf("overflowing overrides should not start a game")
+	}
+}
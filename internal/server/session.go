@@ -67,22 +67,37 @@ func (s *Session) HandleConnection(conn *websocket.Conn) {
 }
 
 type ClientMessage struct {
-	Type      string     `json:"type"`
-	ActionId  string     `json:"actionId,omitempty"`
-	Action    *ActionDTO `json:"action,omitempty"`
-	Ruleset   string     `json:"ruleset,omitempty"`
-	RequestId string     `json:"requestId,omitempty"`
+	Type      string          `json:"type"`
+	ActionId  string          `json:"actionId,omitempty"`
+	Action    *ActionDTO      `json:"action,omitempty"`
+	Ruleset   string          `json:"ruleset,omitempty"`
+	Rules     json.RawMessage `json:"rules,omitempty"`
+	RequestId string          `json:"requestId,omitempty"`
 }
 
 type ServerMessage struct {
-	Type   string     `json:"type"`
-	State  *GameView  `json:"state,omitempty"`
-	Events []Event    `json:"events,omitempty"`
-	Error  *ErrorView `json:"error,omitempty"`
+	Type     string             `json:"type"`
+	State    *GameView          `json:"state,omitempty"`
+	Events   []Event            `json:"events,omitempty"`
+	Error    *ErrorView         `json:"error,omitempty"`
+	Rulesets []RulesetView      `json:"rulesets,omitempty"`
+	History  []RoundSummaryView `json:"history,omitempty"`
+}
+
+type RulesetView struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Rules       RulesView `json:"rules"`
 }
 
 type ErrorView struct {
-	Code    string `json:"code"`
+	Code    string           `json:"code"`
+	Message string           `json:"message"`
+	Fields  []FieldErrorView `json:"fields,omitempty"`
+}
+
+type FieldErrorView struct {
+	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
@@ -97,9 +112,13 @@ func (s *Session) handleMessage(msg ClientMessage) {
 	case "join_session":
 		s.sendState(nil)
 	case "start_game":
-		s.startGame(msg.Ruleset)
+		s.startGame(msg.Ruleset, msg.Rules)
+	case "list_rulesets":
+		s.sendRulesets()
 	case "request_state":
 		s.sendState(nil)
+	case "request_history":
+		s.sendHistory()
 	case "player_action":
 		s.applyAction(msg.ActionId, msg.Action)
 	default:
@@ -107,21 +126,53 @@ func (s *Session) handleMessage(msg ClientMessage) {
 	}
 }
 
-func (s *Session) startGame(ruleset string) {
+// startGame starts a game with the named preset, optionally overridden by
+// a partial rules object.
+func (s *Session) startGame(ruleset string, overrides json.RawMessage) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	rules := engine.TisyachaPreset()
-	s.state = engine.NewGame(rules, time.Now().UnixNano())
+	rules, ok := engine.LookupPreset(ruleset)
+	if !ok {
+		s.sendError("unknown_ruleset", "unknown ruleset "+ruleset)
+		return
+	}
+	rules, err := mergeRules(rules, overrides)
+	if err != nil {
+		s.sendRulesError(err)
+		return
+	}
+	if err := s.newGameLocked(rules, engine.RandomSeed()); err != nil {
+		s.sendRulesError(err)
+		return
+	}
+	s.sendStateLocked(dealCommittedEvents(s.state))
+	s.botAutoPlayLocked()
+}
+
+// newGameLocked starts a fresh game with the human in seat 0 and bots in
+// every other seat. Deals use the v2 stable shuffler so every revealed
+// round seed can be replayed by the clients without giving away the game
+// seed. Invalid rules leave the current game untouched.
+func (s *Session) newGameLocked(rules engine.Rules, seed int64) error {
+	state, err := engine.NewGame(rules, seed)
+	if err != nil {
+		return err
+	}
+	state.Shuffler = engine.StableShufflerV2{}
+	s.state = state
 	engine.DealRound(&s.state)
 	s.started = true
 	s.actionIds = map[string]bool{}
-	s.botPlayers = map[int]bots.Bot{
-		1: bots.NewEasy(s.state.Seed + 1),
-		2: bots.NewNormal(s.state.Seed + 2),
+	s.botPlayers = map[int]bots.Bot{}
+	for p := 1; p < rules.Players; p++ {
+		if p%2 == 1 {
+			s.botPlayers[p] = bots.NewEasy(s.state.Seed + int64(p))
+		} else {
+			s.botPlayers[p] = bots.NewNormal(s.state.Seed + int64(p))
+		}
 	}
-	s.sendStateLocked(nil)
-	s.botAutoPlayLocked()
+	return nil
 }
 
 func (s *Session) applyAction(actionId string, dto *ActionDTO) {
@@ -142,7 +193,7 @@ func (s *Session) applyAction(actionId string, dto *ActionDTO) {
 	}
 	s.actionIds[actionId] = true
 
-	prev := s.state
+	prev := s.state.Clone()
 	action, err := dto.ToEngine()
 	if err != nil {
 		s.sendError("bad_action", err.Error())
@@ -155,8 +206,8 @@ func (s *Session) applyAction(actionId string, dto *ActionDTO) {
 		return
 	}
 	log.Printf("player action applied: phase=%v", s.state.Round.Phase)
-	s.ensureDealLocked()
 	events := buildEvents(prev, s.state, player, action)
+	events = append(events, s.ensureDealLocked()...)
 	s.sendStateLocked(events)
 	s.botAutoPlayLocked()
 }
@@ -186,7 +237,7 @@ func (s *Session) botAutoPlayLocked() {
 			s.sendError("bot_no_actions", "bot has no legal actions")
 			return
 		}
-		prev := s.state
+		prev := s.state.Clone()
 		action := bot.ChooseAction(s.state, player)
 		log.Printf("bot action: p=%d phase=%v action=%v", player, s.state.Round.Phase, action.Type)
 		if err := engine.ApplyAction(&s.state, player, action); err != nil {
@@ -200,16 +251,20 @@ func (s *Session) botAutoPlayLocked() {
 			}
 			log.Printf("bot fallback applied: p=%d phase=%v action=%v", player, s.state.Round.Phase, action.Type)
 		}
-		s.ensureDealLocked()
 		events := buildEvents(prev, s.state, player, action)
+		events = append(events, s.ensureDealLocked()...)
 		s.sendStateLocked(events)
 	}
 }
 
-func (s *Session) ensureDealLocked() {
+// ensureDealLocked deals the next round once the previous one has ended and
+// returns the commitment event for the new deal, if it has one.
+func (s *Session) ensureDealLocked() []Event {
 	if s.state.Round.Phase == engine.PhaseDeal && !s.state.Round.HandsDealt {
 		engine.DealRound(&s.state)
+		return dealCommittedEvents(s.state)
 	}
+	return nil
 }
 
 func fallbackAction(state engine.GameState, player int, legal []engine.Action) engine.Action {
@@ -238,8 +293,8 @@ func fallbackAction(state engine.GameState, player int, legal []engine.Action) e
 		// sort by lowest points then rank strength
 		for i := 0; i < len(hand); i++ {
 			for j := i + 1; j < len(hand); j++ {
-				pi := engine.CardPoints(hand[i].Rank)
-				pj := engine.CardPoints(hand[j].Rank)
+				pi := state.Rules.CardValue(hand[i].Rank)
+				pj := state.Rules.CardValue(hand[j].Rank)
 				if pj < pi || (pj == pi && engine.RankStrength(hand[j].Rank) < engine.RankStrength(hand[i].Rank)) {
 					hand[i], hand[j] = hand[j], hand[i]
 				}
@@ -256,7 +311,7 @@ func fallbackAction(state engine.GameState, player int, legal []engine.Action) e
 			if a.Type != engine.ActionPlayCard || a.Card == nil {
 				continue
 			}
-			score := engine.CardPoints(a.Card.Rank)*10 + engine.RankStrength(a.Card.Rank)
+			score := state.Rules.CardValue(a.Card.Rank)*10 + engine.RankStrength(a.Card.Rank)
 			if best == -1 || score < best {
 				best = score
 				lowest = a
@@ -282,7 +337,7 @@ func (s *Session) sendStateLocked(events []Event) {
 		return
 	}
 	if !s.started {
-		s.state = engine.NewGame(engine.TisyachaPreset(), 0)
+		s.state, _ = engine.NewGame(engine.TisyachaPreset(), 0)
 	}
 	msg := ServerMessage{
 		Type:   "state",
@@ -307,6 +362,49 @@ func (s *Session) sendError(code, message string) {
 	_ = s.conn.WriteJSON(msg)
 }
 
+func (s *Session) sendRulesets() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return
+	}
+	presets := engine.Presets()
+	views := make([]RulesetView, 0, len(presets))
+	for _, p := range presets {
+		views = append(views, RulesetView{Name: p.Name, Description: p.Description, Rules: buildRulesView(p.Rules())})
+	}
+	_ = s.conn.WriteJSON(ServerMessage{Type: "rulesets", Rulesets: views})
+}
+
+// sendHistory sends every finished round of the current game.
+func (s *Session) sendHistory() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return
+	}
+	if !s.started {
+		s.sendError("not_started", "game not started")
+		return
+	}
+	_ = s.conn.WriteJSON(ServerMessage{Type: "history", History: buildHistoryView(s.state)})
+}
+
+// sendRulesError reports a rejected ruleset along with every offending field.
+func (s *Session) sendRulesError(err error) {
+	if s.conn == nil {
+		return
+	}
+	log.Printf("ws error: code=invalid_rules detail=%s", err)
+	view := &ErrorView{Code: "invalid_rules", Message: translateErrorMessage("invalid_rules", err.Error())}
+	if errs, ok := err.(engine.RuleErrors); ok {
+		for _, e := range errs {
+			view.Fields = append(view.Fields, FieldErrorView{Field: e.Field, Message: e.Message})
+		}
+	}
+	_ = s.conn.WriteJSON(ServerMessage{Type: "error", Error: view})
+}
+
 func translateErrorMessage(code, detail string) string {
 	switch code {
 	case "bad_request":
@@ -325,6 +423,10 @@ func translateErrorMessage(code, detail string) string {
 		return "Бот не может сделать ход"
 	case "bot_action_failed":
 		return "Бот не смог выполнить ход"
+	case "unknown_ruleset":
+		return "Неизвестный набор правил"
+	case "invalid_rules":
+		return "Некорректные правила игры"
 	default:
 		return "Произошла ошибка"
 	}
@@ -3,12 +3,22 @@ package server
 import (
 	"testing"
 
+	"thousand/internal/bots"
 	"thousand/internal/engine"
 )
 
+func newTestGame(t *testing.T, r engine.Rules, seed int64) engine.GameState {
+	t.Helper()
+	g, err := engine.NewGame(r, seed)
+	if err != nil {
+		t.Fatalf("new game: %v", err)
+	}
+	return g
+}
+
 func TestFallbackActionIsLegal(t *testing.T) {
 	r := engine.TisyachaPreset()
-	g := engine.NewGame(r, 1)
+	g := newTestGame(t, r, 1)
 	engine.DealRound(&g)
 
 	// Bidding
@@ -50,3 +60,96 @@ func TestFallbackActionIsLegal(t *testing.T) {
 		t.Fatalf("fallback action invalid in play: %v", err)
 	}
 }
+
+func TestSessionPlaysTwoPlayerRounds(t *testing.T) {
+	s := &Session{}
+	if err := s.newGameLocked(engine.TwoPlayerPreset(), 12); err != nil {
+		t.Fatalf("new game: %v", err)
+	}
+	s.botPlayers[0] = bots.NewNormal(11)
+	s.botPlayers[1] = bots.NewNormal(12)
+	s.botAutoPlayLocked()
+
+	scored := false
+	for _, p := range s.state.Players {
+		if p.GameScore != 0 {
+			scored = true
+		}
+	}
+	if !scored {
+		t.Fatalf("expected bots to finish at least one scored round")
+	}
+}
+
+func TestCardDTORoundTripLowRanks(t *testing.T) {
+	for _, rank := range []engine.Rank{engine.Rank7, engine.Rank8} {
+		card := engine.Card{Suit: engine.SuitDiamonds, Rank: rank}
+		back, err := cardToDTO(card).toEngine()
+		if err != nil || back != card {
+			t.Fatalf("round trip of %v failed: %v %v", card, back, err)
+		}
+	}
+}
+
+func TestNewGameRejectsInvalidRules(t *testing.T) {
+	s := &Session{}
+	rules := engine.TisyachaPreset()
+	rules.BidStep = 0
+	err := s.newGameLocked(rules, 1)
+	if _, ok := err.(engine.RuleErrors); !ok {
+		t.Fatalf("expected rule errors, got %v", err)
+	}
+	if s.started {
+		t.Fatalf("session should not start with invalid rules")
+	}
+}
+
+func TestStartGameSelectsRuleset(t *testing.T) {
+	s := &Session{}
+	s.startGame("two_player", nil)
+	if !s.started || len(s.state.Players) != 2 {
+		t.Fatalf("expected a two-player game, got %d players", len(s.state.Players))
+	}
+	if s.state.Shuffler == nil || s.state.Shuffler.Name() != "pcg32-v2" {
+		t.Fatalf("expected server deals to use the one-way seed derivation, got %v", s.state.Shuffler)
+	}
+
+	s = &Session{}
+	s.startGame("no_such_rules", nil)
+	if s.started {
+		t.Fatalf("unknown ruleset should not start a game")
+	}
+}
+
+func TestHistoryViewCoversPlayedRounds(t *testing.T) {
+	s := &Session{}
+	if err := s.newGameLocked(engine.TwoPlayerPreset(), 12); err != nil {
+		t.Fatalf("new game: %v", err)
+	}
+	s.botPlayers[0] = bots.NewNormal(11)
+	s.botPlayers[1] = bots.NewNormal(12)
+	s.botAutoPlayLocked()
+
+	history := buildHistoryView(s.state)
+	if len(history) == 0 || len(history) != len(s.state.History) {
+		t.Fatalf("expected every finished round in the view, got %d of %d", len(history), len(s.state.History))
+	}
+	last := history[len(history)-1]
+	for i, p := range s.state.Players {
+		if last.Scores[i] != p.GameScore {
+			t.Fatalf("last summary scores %v do not match the game", last.Scores)
+		}
+	}
+	played := false
+	for i, r := range history {
+		if r.Number < 1 || r.Outcome == "" {
+			t.Fatalf("summary %d missing number or outcome: %+v", i, r)
+		}
+		if r.Outcome == "scored" && len(r.Tricks) > 0 {
+			played = true
+		}
+	}
+	if !played {
+		t.Fatalf("expected at least one scored round with tricks")
+	}
+}
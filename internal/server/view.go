@@ -12,6 +12,7 @@ type PlayerView struct {
 	Bolts          int       `json:"bolts"`
 	OnBarrel       bool      `json:"onBarrel"`
 	BarrelAttempts int       `json:"barrelAttempts"`
+	SittingOut     bool      `json:"sittingOut"`
 }
 
 type RoundView struct {
@@ -20,9 +21,16 @@ type RoundView struct {
 	Leader        int          `json:"leader"`
 	Trump         *string      `json:"trump,omitempty"`
 	KittyCount    int          `json:"kittyCount"`
+	Kitty         []CardDTO    `json:"kitty,omitempty"`
+	DeadHandCount int          `json:"deadHandCount"`
 	BidTurn       int          `json:"bidTurn"`
 	BidWinner     int          `json:"bidWinner"`
 	BidValue      int          `json:"bidValue"`
+	Multiplier    int          `json:"multiplier"`
+	ContraBy      int          `json:"contraBy"`
+	ForcedBid     bool         `json:"forcedBid"`
+	Raspasy       bool         `json:"raspasy"`
+	KittyOwner    int          `json:"kittyOwner"`
 	Bids          map[int]int  `json:"bids"`
 	Passed        map[int]bool `json:"passed"`
 	TrickCards    []CardDTO    `json:"trickCards"`
@@ -42,17 +50,6 @@ type GameView struct {
 	Meta         MetaView     `json:"meta"`
 }
 
-type RulesView struct {
-	DealHandSize   int `json:"dealHandSize"`
-	PlayHandSize   int `json:"playHandSize"`
-	KittySize      int `json:"kittySize"`
-	BidMin         int `json:"bidMin"`
-	BidStep        int `json:"bidStep"`
-	MaxBid         int `json:"maxBid"`
-	SnosCards      int `json:"snosCards"`
-	BarrelAttempts int `json:"barrelAttempts"`
-}
-
 type MetaView struct {
 	SessionID string `json:"sessionId"`
 	PlayerID  int    `json:"playerId"`
@@ -74,6 +71,7 @@ func BuildGameView(g engine.GameState, viewer int, sessionID string) *GameView {
 			Bolts:          p.Bolts,
 			OnBarrel:       p.OnBarrel,
 			BarrelAttempts: p.BarrelAttempts,
+			SittingOut:     !engine.InRound(g, i),
 		}
 		if i == viewer {
 			for _, c := range p.Hand {
@@ -91,6 +89,10 @@ func BuildGameView(g engine.GameState, viewer int, sessionID string) *GameView {
 	for _, c := range g.Round.TrickCards {
 		trickCards = append(trickCards, cardToDTO(c))
 	}
+	var kitty []CardDTO
+	for _, c := range g.Round.RevealedKitty {
+		kitty = append(kitty, cardToDTO(c))
+	}
 	legal := []ActionDTO{}
 	for _, a := range engine.LegalActions(g, viewer) {
 		legal = append(legal, ActionFromEngine(a))
@@ -104,9 +106,16 @@ func BuildGameView(g engine.GameState, viewer int, sessionID string) *GameView {
 			Leader:        g.Round.Leader,
 			Trump:         trump,
 			KittyCount:    len(g.Round.Kitty),
+			Kitty:         kitty,
+			DeadHandCount: len(g.Round.DeadHand),
 			BidTurn:       g.Round.BidTurn,
 			BidWinner:     g.Round.BidWinner,
 			BidValue:      g.Round.BidValue,
+			Multiplier:    g.Round.StakeMultiplier(),
+			ContraBy:      g.Round.ContraBy,
+			ForcedBid:     g.Round.ForcedBid,
+			Raspasy:       g.Round.Raspasy,
+			KittyOwner:    g.Round.KittyOwner,
 			Bids:          g.Round.Bids,
 			Passed:        g.Round.Passed,
 			TrickCards:    trickCards,
@@ -116,16 +125,7 @@ func BuildGameView(g engine.GameState, viewer int, sessionID string) *GameView {
 			CurrentPlayer: currentPlayer,
 			HasCurrent:    hasCurrent,
 		},
-		Rules: RulesView{
-			DealHandSize:   g.Rules.DealHandSize,
-			PlayHandSize:   g.Rules.PlayHandSize,
-			KittySize:      g.Rules.KittySize,
-			BidMin:         g.Rules.BidMin,
-			BidStep:        g.Rules.BidStep,
-			MaxBid:         g.Rules.MaxBid,
-			SnosCards:      g.Rules.SnosCards,
-			BarrelAttempts: g.Rules.BarrelAttempts,
-		},
+		Rules:        buildRulesView(g.Rules),
 		LegalActions: legal,
 		Effects: EffectsView{
 			Dumped: append([]int(nil), g.LastRoundEffects.Dumped...),
@@ -155,6 +155,12 @@ func phaseToString(p engine.Phase) string {
 		return "ScoreRound"
 	case engine.PhaseGameOver:
 		return "GameOver"
+	case engine.PhaseContra:
+		return "Contra"
+	case engine.PhaseRospis:
+		return "Rospis"
+	case engine.PhaseRaise:
+		return "Raise"
 	default:
 		return "Unknown"
 	}
@@ -28,7 +28,7 @@ export class CardTextureFactory {
     this.app = app
     this.back = this.createBackTexture()
     const suits: Card['suit'][] = ['C', 'D', 'H', 'S']
-    const ranks: Card['rank'][] = ['9', '10', 'J', 'Q', 'K', 'A']
+    const ranks: Card['rank'][] = ['7', '8', '9', '10', 'J', 'Q', 'K', 'A']
     for (const s of suits) {
       for (const r of ranks) {
         const c: Card = { suit: s, rank: r }
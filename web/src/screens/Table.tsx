@@ -88,6 +88,7 @@ export default function Table() {
 
   const legalActions = state?.legalActions ?? []
   const legalBids = legalActions.filter((a) => a.type === 'bid')
+  const legalRaises = legalActions.filter((a) => a.type === 'raise_contract')
   const canPass = legalActions.some((a) => a.type === 'pass')
   const canAct = legalActions.length > 0
   const hasAction = (type: string) => legalActions.some((a) => a.type === type)
@@ -152,6 +153,10 @@ export default function Table() {
       }
       return
     }
+    if (phase === 'Raise') {
+      if (canPass) sendActionOnSocket({ type: 'pass' })
+      return
+    }
     if (phase === 'Snos') {
       const count = state.rules.snosCards ?? 2
       const picked = pickLowestPoints(hand, count)
@@ -347,6 +352,11 @@ export default function Table() {
                 <button className="secondary" disabled={maxBid === null} onClick={() => setSelectedBid(maxBid)}>
                   Макс
                 </button>
+                {hasAction('request_redeal') && (
+                  <button className="secondary" onClick={() => sendActionOnSocket({ type: 'request_redeal' })}>
+                    Пересдача
+                  </button>
+                )}
               </div>
             </div>
           )}
@@ -359,6 +369,59 @@ export default function Table() {
               >
                 Взять прикуп
               </button>
+              {hasAction('request_redeal') && (
+                <button className="secondary" onClick={() => sendActionOnSocket({ type: 'request_redeal' })}>
+                  Пересдача
+                </button>
+              )}
+            </div>
+          )}
+          {state?.round.phase === 'Raise' && (
+            <div className="action-row">
+              <button className="primary" disabled={!canPass} onClick={() => sendActionOnSocket({ type: 'pass' })}>
+                Оставить {state.round.bidValue}
+              </button>
+              {legalRaises.map((a) => (
+                <button
+                  key={a.bid}
+                  className="secondary"
+                  onClick={() => sendActionOnSocket({ type: 'raise_contract', bid: a.bid })}
+                >
+                  {a.bid}
+                </button>
+              ))}
+            </div>
+          )}
+          {state?.round.phase === 'Contra' && (
+            <div className="action-row">
+              <button className="primary" disabled={!canPass} onClick={() => sendActionOnSocket({ type: 'pass' })}>
+                Играть
+              </button>
+              {hasAction('contra') && (
+                <button className="primary" onClick={() => sendActionOnSocket({ type: 'contra' })}>
+                  Контра
+                </button>
+              )}
+              {hasAction('recontra') && (
+                <button className="primary" onClick={() => sendActionOnSocket({ type: 'recontra' })}>
+                  Реконтра
+                </button>
+              )}
+              <div className="action-note">Ставки: ×{state.round.multiplier}</div>
+            </div>
+          )}
+          {state?.round.phase === 'Rospis' && (
+            <div className="action-row">
+              <button className="primary" disabled={!canPass} onClick={() => sendActionOnSocket({ type: 'pass' })}>
+                Принять роспись
+              </button>
+              <button
+                className="secondary"
+                disabled={!hasAction('refuse_rospis')}
+                onClick={() => sendActionOnSocket({ type: 'refuse_rospis' })}
+              >
+                Играть
+              </button>
             </div>
           )}
           {state?.round.phase === 'Snos' && (
@@ -422,8 +485,20 @@ export default function Table() {
             {state && (
               <div className="info">
                 <div>Фаза: {phaseLabel(state.round.phase)}</div>
-                <div>Ставка: {state.round.bidValue || '-'}</div>
+                <div>
+                  Ставка: {state.round.bidValue || '-'}
+                  {state.round.forcedBid && ' (обязательная)'}
+                </div>
+                {state.round.raspasy && (
+                  <div>
+                    Распасы{state.round.kittyOwner >= 0 ? ` • прикуп у игрока ${state.round.kittyOwner}` : ''}
+                  </div>
+                )}
                 <div>Козырь: {state.round.trump ? suitGlyph(state.round.trump) : '-'}</div>
+                {state.round.kitty && state.round.kitty.length > 0 && (
+                  <div>Прикуп: {formatCards(state.round.kitty)}</div>
+                )}
+                {state.round.deadHandCount > 0 && <div>Мёртвая рука: {state.round.deadHandCount} карт</div>}
               </div>
             )}
             {state && (
@@ -513,6 +588,14 @@ function formatEvent(e: any) {
       return `Игрок ${p} поставил ${e.data?.bid}`
     case 'bid_passed':
       return `Игрок ${p} пас`
+    case 'all_passed':
+      return formatAllPassed(p, e.data?.reason, e.data?.bid)
+    case 'redeal_requested':
+      return `Игрок ${p} просит пересдачу${redealReasonLabel(e.data?.reason)}: ${formatCards(e.data?.cards ?? [])}`
+    case 'kitty_awarded':
+      return `Прикуп распасов достался игроку ${p}: ${formatCards(e.data?.cards ?? [])} (+${e.data?.value ?? 0})`
+    case 'kitty_revealed':
+      return `Прикуп открыт для всех: ${formatCards(e.data?.cards ?? [])}`
     case 'kitty_taken':
       return `Игрок ${p} взял прикуп`
     case 'snos_made':
@@ -529,6 +612,22 @@ function formatEvent(e: any) {
       return `${formatRoundScore(e.data?.points ?? [])} • Начинается новый кон`
     case 'rospis_declared':
       return `Игрок ${p} объявил роспись`
+    case 'rospis_accepted':
+      return `Игрок ${p} принял роспись`
+    case 'rospis_refused':
+      return `Игрок ${p} отказался от росписи — играем`
+    case 'rospis_settled':
+      return formatRospis(p, e.data?.points ?? [])
+    case 'contract_raised':
+      return `Игрок ${p} повысил заказ до ${e.data?.bid}`
+    case 'contract_kept':
+      return `Игрок ${p} оставил заказ ${e.data?.bid}`
+    case 'contra_declared':
+      return `Игрок ${p} объявил контру (×${e.data?.value ?? 2})`
+    case 'recontra_declared':
+      return `Игрок ${p} объявил реконтру (×${e.data?.value ?? 4})`
+    case 'contra_passed':
+      return `Игрок ${p} без контры`
     case 'bolt_awarded':
       return `Игрок ${p} получил болт`
     case 'bolt_penalty':
@@ -537,12 +636,22 @@ function formatEvent(e: any) {
       return `Игрок ${p} сел на бочку`
     case 'barrel_exit':
       return `Игрок ${p} сошёл с бочки`
+    case 'barrel_pushed':
+      return `Игрок ${p} сбит с бочки${e.data?.value ? ` (-${e.data.value})` : ''}`
     case 'barrel_penalty':
       return `Игрок ${p} получил штраф за бочку (-${e.data?.value ?? 0})`
+    case 'defender_capped':
+      return `Игрок ${p} упёрся в потолок защитника — не засчитано ${e.data?.value ?? 0}`
     case 'dump_reset':
       return `Игрок ${p} попал на самосвал — счёт обнулён`
     case 'game_ended':
-      return `Игра окончена. Победил игрок ${p}`
+      return `Игра окончена. Победил игрок ${p}${winReasonLabel(e.data?.reason)} • ${formatStandings(e.data?.standings ?? [])}`
+    case 'deal_committed':
+      return `Раздача ${e.data?.value ?? ''}: хеш колоды ${String(e.data?.commitment ?? '').slice(0, 12)}…`
+    case 'deal_revealed':
+      return `Раздача ${e.data?.value ?? ''}: зерно ${e.data?.seed ?? ''} раскрыто для проверки`
+    case 'game_tied':
+      return `Ничья на вершине — игра продолжается • ${formatStandings(e.data?.standings ?? [])}`
     default:
       return 'Неизвестное событие'
   }
@@ -553,6 +662,10 @@ function formatCard(card?: { rank: string; suit: string }) {
   return `${rankLabel(card.rank)}${suitGlyph(card.suit)}`
 }
 
+function formatCards(cards: Array<{ rank: string; suit: string }>) {
+  return cards.map((c) => formatCard(c)).join(' ')
+}
+
 function suitGlyph(suit?: string) {
   switch (suit) {
     case 'H':
@@ -578,8 +691,14 @@ function phaseLabel(phase?: string) {
       return 'Торги'
     case 'KittyTake':
       return 'Прикуп'
+    case 'Raise':
+      return 'Повышение'
     case 'Snos':
       return 'Снос'
+    case 'Contra':
+      return 'Контра'
+    case 'Rospis':
+      return 'Роспись'
     case 'PlayTricks':
       return 'Ход'
     case 'ScoreRound':
@@ -597,8 +716,14 @@ function phaseHelp(phase?: string) {
       return 'Торги: выберите ставку или Пас.'
     case 'KittyTake':
       return 'Прикуп: возьмите 3 карты.'
+    case 'Raise':
+      return 'Повышение: заказчик может один раз поднять заказ после прикупа.'
     case 'Snos':
       return 'Снос: отдайте по одной карте каждому сопернику.'
+    case 'Contra':
+      return 'Контра: защитники могут удвоить ставку, заказчик — удвоить ещё раз.'
+    case 'Rospis':
+      return 'Роспись: защитники принимают её или заставляют играть.'
     case 'PlayTricks':
       return 'Ход: выберите карту, следуйте масти.'
     case 'ScoreRound':
@@ -614,8 +739,14 @@ function phaseInstruction(phase?: string) {
       return 'Торги: выберите ставку или Пас'
     case 'KittyTake':
       return 'Прикуп: возьмите карты'
+    case 'Raise':
+      return 'Повышение: поднимите заказ или оставьте его'
     case 'Snos':
       return 'Снос: выберите 2 карты и отдайте соперникам'
+    case 'Contra':
+      return 'Контра: удвойте ставку или играйте'
+    case 'Rospis':
+      return 'Роспись: примите или откажитесь'
     case 'PlayTricks':
       return 'Ход: выберите подсвеченную карту'
     case 'ScoreRound':
@@ -635,6 +766,55 @@ function formatSnos(player: number, transfers: Array<{ to: number; card: { rank:
   return `Игрок ${player} сделал снос: отдал ${parts.join(' и ')}`
 }
 
+function formatAllPassed(player: number, reason?: string, bid?: number) {
+  switch (reason) {
+    case 'forced_bid':
+      return `Все спасовали — игрок ${player} обязан играть ${bid ?? ''}`
+    case 'raspasy':
+      return `Все спасовали — играем распасы, начинает игрок ${player}`
+    default:
+      return `Все спасовали — пересдача, сдаёт игрок ${player}`
+  }
+}
+
+function formatRospis(bidder: number, points: number[]) {
+  const parts = points
+    .map((pts, idx) => ({ pts, idx }))
+    .filter(({ idx }) => idx !== bidder)
+    .map(({ pts, idx }) => `игроку ${idx}: +${pts}`)
+  return `Роспись игрока ${bidder}: ${points[bidder] ?? 0}, ${parts.join(', ')}`
+}
+
+function redealReasonLabel(reason?: string) {
+  switch (reason) {
+    case 'four_nines':
+      return ' (четыре девятки)'
+    case 'weak_hand':
+      return ' (слабая рука)'
+    case 'weak_kitty':
+      return ' (слабый прикуп)'
+    default:
+      return ''
+  }
+}
+
+function winReasonLabel(reason?: string) {
+  switch (reason) {
+    case 'bidder':
+      return ' (приоритет заказчика)'
+    case 'highest_score':
+      return ' (больше очков)'
+    case 'seat_order':
+      return ' (по порядку мест)'
+    default:
+      return ''
+  }
+}
+
+function formatStandings(standings: Array<{ player: number; score: number }>) {
+  return standings.map((s, idx) => `${idx + 1}. игрок ${s.player}: ${s.score}`).join(', ')
+}
+
 function formatRoundScore(points: number[]) {
   if (!points || points.length === 0) return 'Итог кона: очки не рассчитаны'
   const parts = points.map((p, idx) => `игрок ${idx}: ${p}`)
@@ -1,5 +1,5 @@
 export type Suit = 'C' | 'D' | 'H' | 'S'
-export type Rank = '9' | '10' | 'J' | 'Q' | 'K' | 'A'
+export type Rank = '7' | '8' | '9' | '10' | 'J' | 'Q' | 'K' | 'A'
 
 export type Card = {
   suit: Suit
@@ -25,6 +25,7 @@ export type PlayerView = {
   bolts: number
   onBarrel: boolean
   barrelAttempts: number
+  sittingOut: boolean
 }
 
 export type RoundView = {
@@ -33,9 +34,16 @@ export type RoundView = {
   leader: number
   trump?: Suit
   kittyCount: number
+  kitty?: Card[]
+  deadHandCount: number
   bidTurn: number
   bidWinner: number
   bidValue: number
+  multiplier: number
+  contraBy: number
+  forcedBid: boolean
+  raspasy: boolean
+  kittyOwner: number
   bids?: Record<string, number>
   passed?: Record<string, boolean>
   trickCards: Card[]
@@ -46,19 +54,65 @@ export type RoundView = {
   hasCurrent: boolean
 }
 
+export type RulesView = {
+  players: number
+  deckRanks: Rank[]
+  dealHandSize: number
+  playHandSize: number
+  kittySize: number
+  snosCards: number
+  bidMin: number
+  bidStep: number
+  maxBid: number
+  winScore: number
+  mustFollowSuit: boolean
+  mustTrumpIfVoid: boolean
+  mustOverTrump: boolean
+  contractScoresAsBid: boolean
+  contractFailPenaltyBid: boolean
+  marriageRequiresTrick: boolean
+  aceMarriageEnabled: boolean
+  barrelThreshold: number
+  barrelTarget: number
+  barrelAttempts: number
+  boltPenalty: number
+  boltEvery: number
+  dumpThreshold: number
+  dumpNegativeThreshold: number
+  dealerSitsOut: boolean
+  dealerScoring: 'nothing' | 'kitty'
+  deadHandSize: number
+  snosToDeadHand: boolean
+  allowContractRaise: boolean
+  kittyFaceUp: boolean
+  allPassPolicy: 'redeal' | 'forced_bid' | 'raspasy'
+  forcedBidSeat: number
+  forcedBidValue: number
+  raspasyKitty: 'set_aside' | 'first_trick'
+  marriageOnLeadOnly: boolean
+  bidCapWithoutMarriage: number
+  redealFourNines: boolean
+  redealHandBelow: number
+  redealKittyBelow: number
+  barrelPolicy: 'single_owner' | 'push_off_penalty' | 'shared'
+  barrelPushPenalty: number
+  defenderScoreCap: number
+  barrelNeedsContract: boolean
+  pointsRounding: 'none' | 'nearest5' | 'ceil5'
+  cardPoints: Partial<Record<Rank, number>>
+  marriagePoints: Partial<Record<Card['suit'], number>>
+  aceMarriagePoints: number
+  allowContra: boolean
+  rospisPolicy: 'half' | 'half_rounded' | 'fixed'
+  rospisFixedPoints: number
+  rospisRefusable: boolean
+  tiebreakPolicy: 'bidder_first' | 'highest_score' | 'play_on'
+}
+
 export type GameView = {
   players: PlayerView[]
   round: RoundView
-  rules: {
-    dealHandSize: number
-    playHandSize: number
-    kittySize: number
-    bidMin: number
-    bidStep: number
-    maxBid: number
-    snosCards: number
-    barrelAttempts: number
-  }
+  rules: RulesView
   legalActions: ActionDTO[]
   effects: {
     dumped: number[]
@@ -69,6 +123,42 @@ export type GameView = {
   }
 }
 
+export type RoundSummaryView = {
+  number: number
+  dealer: number
+  outcome: 'scored' | 'rospis' | 'all_passed' | 'redeal_requested'
+  bids: { player: number; bid?: number; pass?: boolean }[]
+  contract: number
+  contractValue: number
+  multiplier: number
+  forcedBid: boolean
+  raspasy: boolean
+  trump?: Suit
+  kitty: Card[]
+  snos: Card[]
+  tricks: { plays: { player: number; card: Card }[]; winner: number }[]
+  marriages: { player: number; suit?: Suit; ace?: boolean; points: number }[]
+  points?: number[]
+  effects: {
+    bolts?: number[]
+    boltPenalties?: number[]
+    barrelEnter?: number[]
+    barrelExit?: number[]
+    barrelPenalty?: number[]
+    barrelPushed?: number[]
+    capped?: { player: number; points: number }[]
+    rospis?: { player: number; points: number }[]
+    dumped?: number[]
+    winner: number
+    hasWinner: boolean
+    winReason?: string
+    contenders?: number[]
+  }
+  scores: number[]
+}
+
 export type ServerMessage =
   | { type: 'state'; state: GameView; events?: any[] }
-  | { type: 'error'; error: { code: string; message: string } }
+  | { type: 'rulesets'; rulesets: { name: string; description: string; rules: RulesView }[] }
+  | { type: 'history'; history?: RoundSummaryView[] }
+  | { type: 'error'; error: { code: string; message: string; fields?: { field: string; message: string }[] } }